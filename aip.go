@@ -7,7 +7,6 @@
 package aip
 
 import (
-	"bytes"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
@@ -39,11 +38,12 @@ const (
 
 // Aip is an Author Identity Protocol object
 type Aip struct {
-	Algorithm                 Algorithm `json:"algorithm"`                   // Known AIP algorithm type
-	AlgorithmSigningComponent string    `json:"algorithm_signing_component"` // Changes based on the Algorithm
-	Data                      []string  `json:"data"`                        // Data to be signed or validated
-	Indices                   []int     `json:"indices,omitempty"`           // BOB indices
-	Signature                 string    `json:"signature"`                   // AIP generated signature
+	Algorithm                 Algorithm         `json:"algorithm"`                    // Known AIP algorithm type
+	AlgorithmSigningComponent string            `json:"algorithm_signing_component"`  // Changes based on the Algorithm
+	Data                      []string          `json:"data"`                         // Data to be signed or validated
+	Indices                   []int             `json:"indices,omitempty"`            // BOB indices
+	Signature                 string            `json:"signature"`                    // AIP generated signature
+	SignatureEncoding         SignatureEncoding `json:"signature_encoding,omitempty"` // How the signature cell is pushed (empty = binary, the form Sign sets)
 }
 
 // Validate returns true if the given AIP signature is valid for given data
@@ -85,21 +85,27 @@ func (a *Aip) Validate() (bool, error) {
 
 	}
 
-	// You get the address associated with the pki instead of the current address
-	err = bsm.VerifyMessage(a.AlgorithmSigningComponent, sig, []byte(strings.Join(a.Data, "")))
+	// You get the address associated with the pki instead of the current address (on any network)
+	_, _, err = verifySigner(BitcoinSignedMessage, a.AlgorithmSigningComponent, sig, []byte(strings.Join(a.Data, "")))
 	return err == nil, err
 }
 
 // Sign will provide an AIP signature for a given private key and message using
 // the provided algorithm. It prepends an OP_RETURN to the payload
 func Sign(privateKey *ec.PrivateKey, algorithm Algorithm, message string) (a *Aip, err error) {
+	return SignWithNetwork(privateKey, algorithm, message, Mainnet)
+}
+
+// SignWithNetwork is Sign with the signing address encoded for the given network
+func SignWithNetwork(privateKey *ec.PrivateKey, algorithm Algorithm, message string,
+	network Network) (a *Aip, err error) {
 
 	// Prepend the OP_RETURN to keep consistent with BitcoinFiles SDK
 	// data = append(data, []byte{byte(txscript.OP_RETURN)})
 	prependedData := []string{opReturn, message}
 
-	// Create the base AIP object
-	a = &Aip{Algorithm: algorithm, Data: prependedData}
+	// Create the base AIP object (the signature is pushed as raw bytes unless set otherwise)
+	a = &Aip{Algorithm: algorithm, Data: prependedData, SignatureEncoding: SignatureBinary}
 
 	// Sign using the private key and the message
 	var sig []byte
@@ -110,35 +116,84 @@ func Sign(privateKey *ec.PrivateKey, algorithm Algorithm, message string) (a *Ai
 	a.Signature = base64.StdEncoding.EncodeToString(sig)
 
 	// Store address vs pubkey
+	if a.AlgorithmSigningComponent, err = signingComponent(privateKey, algorithm, network); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// signedPayload joins the data as validation rebuilds it from the tapes
+//
+// Pipes between protocols only split the tapes and are not signed, a pipe
+// before an AIP prefix (or trailing the data) is.
+func signedPayload(data [][]byte) []byte {
+	var payload []byte
+	for i, d := range data {
+		if string(d) == pipe && i < len(data)-1 && string(data[i+1]) != Prefix {
+			continue
+		}
+		payload = append(payload, d...)
+	}
+	return payload
+}
+
+// signingComponent returns the address (or identity key for paymail) stored with the signature
+func signingComponent(privateKey *ec.PrivateKey, algorithm Algorithm, network Network) (string, error) {
 	switch algorithm {
 	case BitcoinECDSA, BitcoinSignedMessage:
 		// Signing component = bitcoin address
 		// Get the address of the private key
-		if add, err := script.NewAddressFromPublicKey(privateKey.PubKey(), true); err != nil {
-			return nil, err
-		} else {
-			a.AlgorithmSigningComponent = add.AddressString
-		}
+		return network.address(privateKey.PubKey(), true)
 	case Paymail:
 		// Signing component = paymail identity key
 		// Get pubKey from private key and overload the address field in AIP
 		// if pubkey, err := bitcoin.PubKeyFromPrivateKeyString(privateKey, false); err != nil {
 		// 	return
 		// }
-		a.AlgorithmSigningComponent = hex.EncodeToString(privateKey.PubKey().Compressed())
+		return hex.EncodeToString(privateKey.PubKey().Compressed()), nil
 	}
+	return "", nil
+}
 
-	return
+// SignOptions are the options used by SignOpReturnDataWithOptions
+type SignOptions struct {
+	Network  Network           // Network of the signing address (default: mainnet)
+	Encoding SignatureEncoding // How the signature is pushed (default: base64, like SignOpReturnData)
 }
 
 // SignOpReturnData will append the given data and return a bt.Output
+//
+// Pipes separating protocols are not signed (they split the tapes), end the data
+// with a pipe to sign it before the AIP prefix. Earlier releases signed every
+// pushed pipe: those signatures validate in legacy mode (InterpretationAllPipes).
 func SignOpReturnData(privateKey *ec.PrivateKey, algorithm Algorithm,
 	data [][]byte) (outData [][]byte, a *Aip, err error) {
+	return SignOpReturnDataWithOptions(privateKey, algorithm, data, nil)
+}
+
+// SignOpReturnDataWithOptions is SignOpReturnData with the network and signature encoding given in the options
+func SignOpReturnDataWithOptions(privateKey *ec.PrivateKey, algorithm Algorithm,
+	data [][]byte, opts *SignOptions) (outData [][]byte, a *Aip, err error) {
+	o := SignOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Network == "" {
+		o.Network = Mainnet
+	}
+	if o.Encoding == "" {
+		o.Encoding = SignatureBase64
+	}
 
 	// Sign with AIP
-	if a, err = Sign(privateKey, algorithm, string(bytes.Join(data, []byte{}))); err != nil {
+	if a, err = SignWithNetwork(privateKey, algorithm, string(signedPayload(data)), o.Network); err != nil {
 		return
 	}
+	var sig []byte
+	if sig, err = a.signaturePush(o.Encoding); err != nil {
+		return nil, nil, err
+	}
+	a.SignatureEncoding = o.Encoding
 
 	// Add AIP signature
 	outData = append(
@@ -146,7 +201,7 @@ func SignOpReturnData(privateKey *ec.PrivateKey, algorithm Algorithm,
 		[]byte(Prefix),
 		[]byte(a.Algorithm),
 		[]byte(a.AlgorithmSigningComponent),
-		[]byte(a.Signature),
+		sig,
 	)
 
 	// // Create the output
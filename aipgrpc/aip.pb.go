@@ -0,0 +1,923 @@
+// AIP signing and verification service
+//
+// The Go code in aip.pb.go and aip_grpc.pb.go is generated from this file
+// (go generate), other languages can generate clients from it as usual.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: aip.proto
+
+package aipgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Aip is an Author Identity Protocol signature and the data it signs
+type Aip struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm                 string                 `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	AlgorithmSigningComponent string                 `protobuf:"bytes,2,opt,name=algorithm_signing_component,json=algorithmSigningComponent,proto3" json:"algorithm_signing_component,omitempty"`
+	Data                      [][]byte               `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty"`
+	Signature                 string                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	SignatureEncoding         string                 `protobuf:"bytes,5,opt,name=signature_encoding,json=signatureEncoding,proto3" json:"signature_encoding,omitempty"` // "binary" or "base64" (how the signature cell is pushed)
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *Aip) Reset() {
+	*x = Aip{}
+	mi := &file_aip_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Aip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aip) ProtoMessage() {}
+
+func (x *Aip) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aip.ProtoReflect.Descriptor instead.
+func (*Aip) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Aip) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *Aip) GetAlgorithmSigningComponent() string {
+	if x != nil {
+		return x.AlgorithmSigningComponent
+	}
+	return ""
+}
+
+func (x *Aip) GetData() [][]byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Aip) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *Aip) GetSignatureEncoding() string {
+	if x != nil {
+		return x.SignatureEncoding
+	}
+	return ""
+}
+
+type SignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm     string                 `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Message       []byte                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignRequest) Reset() {
+	*x = SignRequest{}
+	mi := &file_aip_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRequest) ProtoMessage() {}
+
+func (x *SignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRequest.ProtoReflect.Descriptor instead.
+func (*SignRequest) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignRequest) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *SignRequest) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type SignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Aip           *Aip                   `protobuf:"bytes,1,opt,name=aip,proto3" json:"aip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignResponse) Reset() {
+	*x = SignResponse{}
+	mi := &file_aip_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignResponse) ProtoMessage() {}
+
+func (x *SignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignResponse.ProtoReflect.Descriptor instead.
+func (*SignResponse) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SignResponse) GetAip() *Aip {
+	if x != nil {
+		return x.Aip
+	}
+	return nil
+}
+
+type SignOpReturnDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm     string                 `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Data          [][]byte               `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignOpReturnDataRequest) Reset() {
+	*x = SignOpReturnDataRequest{}
+	mi := &file_aip_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignOpReturnDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignOpReturnDataRequest) ProtoMessage() {}
+
+func (x *SignOpReturnDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignOpReturnDataRequest.ProtoReflect.Descriptor instead.
+func (*SignOpReturnDataRequest) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SignOpReturnDataRequest) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *SignOpReturnDataRequest) GetData() [][]byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SignOpReturnDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          [][]byte               `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Aip           *Aip                   `protobuf:"bytes,2,opt,name=aip,proto3" json:"aip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignOpReturnDataResponse) Reset() {
+	*x = SignOpReturnDataResponse{}
+	mi := &file_aip_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignOpReturnDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignOpReturnDataResponse) ProtoMessage() {}
+
+func (x *SignOpReturnDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignOpReturnDataResponse.ProtoReflect.Descriptor instead.
+func (*SignOpReturnDataResponse) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SignOpReturnDataResponse) GetData() [][]byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SignOpReturnDataResponse) GetAip() *Aip {
+	if x != nil {
+		return x.Aip
+	}
+	return nil
+}
+
+type ValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Aip           *Aip                   `protobuf:"bytes,1,opt,name=aip,proto3" json:"aip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_aip_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ValidateRequest) GetAip() *Aip {
+	if x != nil {
+		return x.Aip
+	}
+	return nil
+}
+
+type ValidateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Signer        string                 `protobuf:"bytes,2,opt,name=signer,proto3" json:"signer,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_aip_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ValidateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ValidateTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RawTx         []byte                 `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"` // "strict" (default) or "legacy"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTransactionRequest) Reset() {
+	*x = ValidateTransactionRequest{}
+	mi := &file_aip_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTransactionRequest) ProtoMessage() {}
+
+func (x *ValidateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*ValidateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ValidateTransactionRequest) GetRawTx() []byte {
+	if x != nil {
+		return x.RawTx
+	}
+	return nil
+}
+
+func (x *ValidateTransactionRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// TransactionResult is the validation of one AIP in a transaction
+type TransactionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vout          uint32                 `protobuf:"varint,1,opt,name=vout,proto3" json:"vout,omitempty"`
+	Algorithm     string                 `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Signer        string                 `protobuf:"bytes,3,opt,name=signer,proto3" json:"signer,omitempty"`
+	Valid         bool                   `protobuf:"varint,4,opt,name=valid,proto3" json:"valid,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionResult) Reset() {
+	*x = TransactionResult{}
+	mi := &file_aip_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionResult) ProtoMessage() {}
+
+func (x *TransactionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionResult.ProtoReflect.Descriptor instead.
+func (*TransactionResult) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TransactionResult) GetVout() uint32 {
+	if x != nil {
+		return x.Vout
+	}
+	return 0
+}
+
+func (x *TransactionResult) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *TransactionResult) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+func (x *TransactionResult) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *TransactionResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ValidateTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Results       []*TransactionResult   `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // Set instead of results when the transaction cannot be read (streaming only)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTransactionResponse) Reset() {
+	*x = ValidateTransactionResponse{}
+	mi := &file_aip_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTransactionResponse) ProtoMessage() {}
+
+func (x *ValidateTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTransactionResponse.ProtoReflect.Descriptor instead.
+func (*ValidateTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidateTransactionResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *ValidateTransactionResponse) GetResults() []*TransactionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ValidateTransactionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ExtractAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RawTx         []byte                 `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractAllRequest) Reset() {
+	*x = ExtractAllRequest{}
+	mi := &file_aip_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractAllRequest) ProtoMessage() {}
+
+func (x *ExtractAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractAllRequest.ProtoReflect.Descriptor instead.
+func (*ExtractAllRequest) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExtractAllRequest) GetRawTx() []byte {
+	if x != nil {
+		return x.RawTx
+	}
+	return nil
+}
+
+// Record is an AIP located in a transaction
+type Record struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vout          uint32                 `protobuf:"varint,1,opt,name=vout,proto3" json:"vout,omitempty"`
+	TapeIndex     uint32                 `protobuf:"varint,2,opt,name=tape_index,json=tapeIndex,proto3" json:"tape_index,omitempty"`
+	Aip           *Aip                   `protobuf:"bytes,3,opt,name=aip,proto3" json:"aip,omitempty"`
+	Valid         bool                   `protobuf:"varint,4,opt,name=valid,proto3" json:"valid,omitempty"`
+	Signer        string                 `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer,omitempty"`
+	Protocols     []string               `protobuf:"bytes,6,rep,name=protocols,proto3" json:"protocols,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	mi := &file_aip_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Record) GetVout() uint32 {
+	if x != nil {
+		return x.Vout
+	}
+	return 0
+}
+
+func (x *Record) GetTapeIndex() uint32 {
+	if x != nil {
+		return x.TapeIndex
+	}
+	return 0
+}
+
+func (x *Record) GetAip() *Aip {
+	if x != nil {
+		return x.Aip
+	}
+	return nil
+}
+
+func (x *Record) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *Record) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+func (x *Record) GetProtocols() []string {
+	if x != nil {
+		return x.Protocols
+	}
+	return nil
+}
+
+type ExtractAllResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Records       []*Record              `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractAllResponse) Reset() {
+	*x = ExtractAllResponse{}
+	mi := &file_aip_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractAllResponse) ProtoMessage() {}
+
+func (x *ExtractAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aip_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractAllResponse.ProtoReflect.Descriptor instead.
+func (*ExtractAllResponse) Descriptor() ([]byte, []int) {
+	return file_aip_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExtractAllResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *ExtractAllResponse) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+var File_aip_proto protoreflect.FileDescriptor
+
+const file_aip_proto_rawDesc = "" +
+	"\n" +
+	"\taip.proto\x12\x06aip.v1\"\xc4\x01\n" +
+	"\x03Aip\x12\x1c\n" +
+	"\talgorithm\x18\x01 \x01(\tR\talgorithm\x12>\n" +
+	"\x1balgorithm_signing_component\x18\x02 \x01(\tR\x19algorithmSigningComponent\x12\x12\n" +
+	"\x04data\x18\x03 \x03(\fR\x04data\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\tR\tsignature\x12-\n" +
+	"\x12signature_encoding\x18\x05 \x01(\tR\x11signatureEncoding\"E\n" +
+	"\vSignRequest\x12\x1c\n" +
+	"\talgorithm\x18\x01 \x01(\tR\talgorithm\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\fR\amessage\"-\n" +
+	"\fSignResponse\x12\x1d\n" +
+	"\x03aip\x18\x01 \x01(\v2\v.aip.v1.AipR\x03aip\"K\n" +
+	"\x17SignOpReturnDataRequest\x12\x1c\n" +
+	"\talgorithm\x18\x01 \x01(\tR\talgorithm\x12\x12\n" +
+	"\x04data\x18\x02 \x03(\fR\x04data\"M\n" +
+	"\x18SignOpReturnDataResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x03(\fR\x04data\x12\x1d\n" +
+	"\x03aip\x18\x02 \x01(\v2\v.aip.v1.AipR\x03aip\"0\n" +
+	"\x0fValidateRequest\x12\x1d\n" +
+	"\x03aip\x18\x01 \x01(\v2\v.aip.v1.AipR\x03aip\"V\n" +
+	"\x10ValidateResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x16\n" +
+	"\x06signer\x18\x02 \x01(\tR\x06signer\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"G\n" +
+	"\x1aValidateTransactionRequest\x12\x15\n" +
+	"\x06raw_tx\x18\x01 \x01(\fR\x05rawTx\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\"\x89\x01\n" +
+	"\x11TransactionResult\x12\x12\n" +
+	"\x04vout\x18\x01 \x01(\rR\x04vout\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12\x16\n" +
+	"\x06signer\x18\x03 \x01(\tR\x06signer\x12\x14\n" +
+	"\x05valid\x18\x04 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"|\n" +
+	"\x1bValidateTransactionResponse\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x123\n" +
+	"\aresults\x18\x02 \x03(\v2\x19.aip.v1.TransactionResultR\aresults\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"*\n" +
+	"\x11ExtractAllRequest\x12\x15\n" +
+	"\x06raw_tx\x18\x01 \x01(\fR\x05rawTx\"\xa6\x01\n" +
+	"\x06Record\x12\x12\n" +
+	"\x04vout\x18\x01 \x01(\rR\x04vout\x12\x1d\n" +
+	"\n" +
+	"tape_index\x18\x02 \x01(\rR\ttapeIndex\x12\x1d\n" +
+	"\x03aip\x18\x03 \x01(\v2\v.aip.v1.AipR\x03aip\x12\x14\n" +
+	"\x05valid\x18\x04 \x01(\bR\x05valid\x12\x16\n" +
+	"\x06signer\x18\x05 \x01(\tR\x06signer\x12\x1c\n" +
+	"\tprotocols\x18\x06 \x03(\tR\tprotocols\"R\n" +
+	"\x12ExtractAllResponse\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12(\n" +
+	"\arecords\x18\x02 \x03(\v2\x0e.aip.v1.RecordR\arecords2\xad\x04\n" +
+	"\n" +
+	"AipService\x121\n" +
+	"\x04Sign\x12\x13.aip.v1.SignRequest\x1a\x14.aip.v1.SignResponse\x12U\n" +
+	"\x10SignOpReturnData\x12\x1f.aip.v1.SignOpReturnDataRequest\x1a .aip.v1.SignOpReturnDataResponse\x12=\n" +
+	"\bValidate\x12\x17.aip.v1.ValidateRequest\x1a\x18.aip.v1.ValidateResponse\x12^\n" +
+	"\x13ValidateTransaction\x12\".aip.v1.ValidateTransactionRequest\x1a#.aip.v1.ValidateTransactionResponse\x12C\n" +
+	"\n" +
+	"ExtractAll\x12\x19.aip.v1.ExtractAllRequest\x1a\x1a.aip.v1.ExtractAllResponse\x12G\n" +
+	"\x0eValidateStream\x12\x17.aip.v1.ValidateRequest\x1a\x18.aip.v1.ValidateResponse(\x010\x01\x12h\n" +
+	"\x19ValidateTransactionStream\x12\".aip.v1.ValidateTransactionRequest\x1a#.aip.v1.ValidateTransactionResponse(\x010\x01B)Z'github.com/bitcoinschema/go-aip/aipgrpcb\x06proto3"
+
+var (
+	file_aip_proto_rawDescOnce sync.Once
+	file_aip_proto_rawDescData []byte
+)
+
+func file_aip_proto_rawDescGZIP() []byte {
+	file_aip_proto_rawDescOnce.Do(func() {
+		file_aip_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_aip_proto_rawDesc), len(file_aip_proto_rawDesc)))
+	})
+	return file_aip_proto_rawDescData
+}
+
+var file_aip_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_aip_proto_goTypes = []any{
+	(*Aip)(nil),                         // 0: aip.v1.Aip
+	(*SignRequest)(nil),                 // 1: aip.v1.SignRequest
+	(*SignResponse)(nil),                // 2: aip.v1.SignResponse
+	(*SignOpReturnDataRequest)(nil),     // 3: aip.v1.SignOpReturnDataRequest
+	(*SignOpReturnDataResponse)(nil),    // 4: aip.v1.SignOpReturnDataResponse
+	(*ValidateRequest)(nil),             // 5: aip.v1.ValidateRequest
+	(*ValidateResponse)(nil),            // 6: aip.v1.ValidateResponse
+	(*ValidateTransactionRequest)(nil),  // 7: aip.v1.ValidateTransactionRequest
+	(*TransactionResult)(nil),           // 8: aip.v1.TransactionResult
+	(*ValidateTransactionResponse)(nil), // 9: aip.v1.ValidateTransactionResponse
+	(*ExtractAllRequest)(nil),           // 10: aip.v1.ExtractAllRequest
+	(*Record)(nil),                      // 11: aip.v1.Record
+	(*ExtractAllResponse)(nil),          // 12: aip.v1.ExtractAllResponse
+}
+var file_aip_proto_depIdxs = []int32{
+	0,  // 0: aip.v1.SignResponse.aip:type_name -> aip.v1.Aip
+	0,  // 1: aip.v1.SignOpReturnDataResponse.aip:type_name -> aip.v1.Aip
+	0,  // 2: aip.v1.ValidateRequest.aip:type_name -> aip.v1.Aip
+	8,  // 3: aip.v1.ValidateTransactionResponse.results:type_name -> aip.v1.TransactionResult
+	0,  // 4: aip.v1.Record.aip:type_name -> aip.v1.Aip
+	11, // 5: aip.v1.ExtractAllResponse.records:type_name -> aip.v1.Record
+	1,  // 6: aip.v1.AipService.Sign:input_type -> aip.v1.SignRequest
+	3,  // 7: aip.v1.AipService.SignOpReturnData:input_type -> aip.v1.SignOpReturnDataRequest
+	5,  // 8: aip.v1.AipService.Validate:input_type -> aip.v1.ValidateRequest
+	7,  // 9: aip.v1.AipService.ValidateTransaction:input_type -> aip.v1.ValidateTransactionRequest
+	10, // 10: aip.v1.AipService.ExtractAll:input_type -> aip.v1.ExtractAllRequest
+	5,  // 11: aip.v1.AipService.ValidateStream:input_type -> aip.v1.ValidateRequest
+	7,  // 12: aip.v1.AipService.ValidateTransactionStream:input_type -> aip.v1.ValidateTransactionRequest
+	2,  // 13: aip.v1.AipService.Sign:output_type -> aip.v1.SignResponse
+	4,  // 14: aip.v1.AipService.SignOpReturnData:output_type -> aip.v1.SignOpReturnDataResponse
+	6,  // 15: aip.v1.AipService.Validate:output_type -> aip.v1.ValidateResponse
+	9,  // 16: aip.v1.AipService.ValidateTransaction:output_type -> aip.v1.ValidateTransactionResponse
+	12, // 17: aip.v1.AipService.ExtractAll:output_type -> aip.v1.ExtractAllResponse
+	6,  // 18: aip.v1.AipService.ValidateStream:output_type -> aip.v1.ValidateResponse
+	9,  // 19: aip.v1.AipService.ValidateTransactionStream:output_type -> aip.v1.ValidateTransactionResponse
+	13, // [13:20] is the sub-list for method output_type
+	6,  // [6:13] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_aip_proto_init() }
+func file_aip_proto_init() {
+	if File_aip_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aip_proto_rawDesc), len(file_aip_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aip_proto_goTypes,
+		DependencyIndexes: file_aip_proto_depIdxs,
+		MessageInfos:      file_aip_proto_msgTypes,
+	}.Build()
+	File_aip_proto = out.File
+	file_aip_proto_goTypes = nil
+	file_aip_proto_depIdxs = nil
+}
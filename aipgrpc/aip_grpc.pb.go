@@ -0,0 +1,357 @@
+// AIP signing and verification service
+//
+// The Go code in aip.pb.go and aip_grpc.pb.go is generated from this file
+// (go generate), other languages can generate clients from it as usual.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: aip.proto
+
+package aipgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AipService_Sign_FullMethodName                      = "/aip.v1.AipService/Sign"
+	AipService_SignOpReturnData_FullMethodName          = "/aip.v1.AipService/SignOpReturnData"
+	AipService_Validate_FullMethodName                  = "/aip.v1.AipService/Validate"
+	AipService_ValidateTransaction_FullMethodName       = "/aip.v1.AipService/ValidateTransaction"
+	AipService_ExtractAll_FullMethodName                = "/aip.v1.AipService/ExtractAll"
+	AipService_ValidateStream_FullMethodName            = "/aip.v1.AipService/ValidateStream"
+	AipService_ValidateTransactionStream_FullMethodName = "/aip.v1.AipService/ValidateTransactionStream"
+)
+
+// AipServiceClient is the client API for AipService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AipServiceClient interface {
+	// Sign signs a message with the server key (OP_RETURN is prepended)
+	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	// SignOpReturnData signs OP_RETURN data and returns it with the AIP appended
+	SignOpReturnData(ctx context.Context, in *SignOpReturnDataRequest, opts ...grpc.CallOption) (*SignOpReturnDataResponse, error)
+	// Validate validates a single AIP
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// ValidateTransaction validates every AIP in a raw transaction
+	ValidateTransaction(ctx context.Context, in *ValidateTransactionRequest, opts ...grpc.CallOption) (*ValidateTransactionResponse, error)
+	// ExtractAll returns every AIP in a raw transaction
+	ExtractAll(ctx context.Context, in *ExtractAllRequest, opts ...grpc.CallOption) (*ExtractAllResponse, error)
+	// ValidateStream validates AIPs in bulk, one response per request in order
+	ValidateStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ValidateRequest, ValidateResponse], error)
+	// ValidateTransactionStream validates transactions in bulk, one response per request in order
+	ValidateTransactionStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ValidateTransactionRequest, ValidateTransactionResponse], error)
+}
+
+type aipServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAipServiceClient(cc grpc.ClientConnInterface) AipServiceClient {
+	return &aipServiceClient{cc}
+}
+
+func (c *aipServiceClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, AipService_Sign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aipServiceClient) SignOpReturnData(ctx context.Context, in *SignOpReturnDataRequest, opts ...grpc.CallOption) (*SignOpReturnDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignOpReturnDataResponse)
+	err := c.cc.Invoke(ctx, AipService_SignOpReturnData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aipServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, AipService_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aipServiceClient) ValidateTransaction(ctx context.Context, in *ValidateTransactionRequest, opts ...grpc.CallOption) (*ValidateTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateTransactionResponse)
+	err := c.cc.Invoke(ctx, AipService_ValidateTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aipServiceClient) ExtractAll(ctx context.Context, in *ExtractAllRequest, opts ...grpc.CallOption) (*ExtractAllResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtractAllResponse)
+	err := c.cc.Invoke(ctx, AipService_ExtractAll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aipServiceClient) ValidateStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ValidateRequest, ValidateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AipService_ServiceDesc.Streams[0], AipService_ValidateStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ValidateRequest, ValidateResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AipService_ValidateStreamClient = grpc.BidiStreamingClient[ValidateRequest, ValidateResponse]
+
+func (c *aipServiceClient) ValidateTransactionStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ValidateTransactionRequest, ValidateTransactionResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AipService_ServiceDesc.Streams[1], AipService_ValidateTransactionStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ValidateTransactionRequest, ValidateTransactionResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AipService_ValidateTransactionStreamClient = grpc.BidiStreamingClient[ValidateTransactionRequest, ValidateTransactionResponse]
+
+// AipServiceServer is the server API for AipService service.
+// All implementations must embed UnimplementedAipServiceServer
+// for forward compatibility.
+type AipServiceServer interface {
+	// Sign signs a message with the server key (OP_RETURN is prepended)
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+	// SignOpReturnData signs OP_RETURN data and returns it with the AIP appended
+	SignOpReturnData(context.Context, *SignOpReturnDataRequest) (*SignOpReturnDataResponse, error)
+	// Validate validates a single AIP
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// ValidateTransaction validates every AIP in a raw transaction
+	ValidateTransaction(context.Context, *ValidateTransactionRequest) (*ValidateTransactionResponse, error)
+	// ExtractAll returns every AIP in a raw transaction
+	ExtractAll(context.Context, *ExtractAllRequest) (*ExtractAllResponse, error)
+	// ValidateStream validates AIPs in bulk, one response per request in order
+	ValidateStream(grpc.BidiStreamingServer[ValidateRequest, ValidateResponse]) error
+	// ValidateTransactionStream validates transactions in bulk, one response per request in order
+	ValidateTransactionStream(grpc.BidiStreamingServer[ValidateTransactionRequest, ValidateTransactionResponse]) error
+	mustEmbedUnimplementedAipServiceServer()
+}
+
+// UnimplementedAipServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAipServiceServer struct{}
+
+func (UnimplementedAipServiceServer) Sign(context.Context, *SignRequest) (*SignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
+}
+func (UnimplementedAipServiceServer) SignOpReturnData(context.Context, *SignOpReturnDataRequest) (*SignOpReturnDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignOpReturnData not implemented")
+}
+func (UnimplementedAipServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedAipServiceServer) ValidateTransaction(context.Context, *ValidateTransactionRequest) (*ValidateTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTransaction not implemented")
+}
+func (UnimplementedAipServiceServer) ExtractAll(context.Context, *ExtractAllRequest) (*ExtractAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtractAll not implemented")
+}
+func (UnimplementedAipServiceServer) ValidateStream(grpc.BidiStreamingServer[ValidateRequest, ValidateResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ValidateStream not implemented")
+}
+func (UnimplementedAipServiceServer) ValidateTransactionStream(grpc.BidiStreamingServer[ValidateTransactionRequest, ValidateTransactionResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ValidateTransactionStream not implemented")
+}
+func (UnimplementedAipServiceServer) mustEmbedUnimplementedAipServiceServer() {}
+func (UnimplementedAipServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeAipServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AipServiceServer will
+// result in compilation errors.
+type UnsafeAipServiceServer interface {
+	mustEmbedUnimplementedAipServiceServer()
+}
+
+func RegisterAipServiceServer(s grpc.ServiceRegistrar, srv AipServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAipServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AipService_ServiceDesc, srv)
+}
+
+func _AipService_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AipServiceServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AipService_Sign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AipServiceServer).Sign(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AipService_SignOpReturnData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignOpReturnDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AipServiceServer).SignOpReturnData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AipService_SignOpReturnData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AipServiceServer).SignOpReturnData(ctx, req.(*SignOpReturnDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AipService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AipServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AipService_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AipServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AipService_ValidateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AipServiceServer).ValidateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AipService_ValidateTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AipServiceServer).ValidateTransaction(ctx, req.(*ValidateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AipService_ExtractAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtractAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AipServiceServer).ExtractAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AipService_ExtractAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AipServiceServer).ExtractAll(ctx, req.(*ExtractAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AipService_ValidateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AipServiceServer).ValidateStream(&grpc.GenericServerStream[ValidateRequest, ValidateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AipService_ValidateStreamServer = grpc.BidiStreamingServer[ValidateRequest, ValidateResponse]
+
+func _AipService_ValidateTransactionStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AipServiceServer).ValidateTransactionStream(&grpc.GenericServerStream[ValidateTransactionRequest, ValidateTransactionResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AipService_ValidateTransactionStreamServer = grpc.BidiStreamingServer[ValidateTransactionRequest, ValidateTransactionResponse]
+
+// AipService_ServiceDesc is the grpc.ServiceDesc for AipService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AipService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aip.v1.AipService",
+	HandlerType: (*AipServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Sign",
+			Handler:    _AipService_Sign_Handler,
+		},
+		{
+			MethodName: "SignOpReturnData",
+			Handler:    _AipService_SignOpReturnData_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _AipService_Validate_Handler,
+		},
+		{
+			MethodName: "ValidateTransaction",
+			Handler:    _AipService_ValidateTransaction_Handler,
+		},
+		{
+			MethodName: "ExtractAll",
+			Handler:    _AipService_ExtractAll_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ValidateStream",
+			Handler:       _AipService_ValidateStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ValidateTransactionStream",
+			Handler:       _AipService_ValidateTransactionStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "aip.proto",
+}
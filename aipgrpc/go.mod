@@ -0,0 +1,21 @@
+module github.com/bitcoinschema/go-aip/aipgrpc
+
+go 1.24.1
+
+require (
+	github.com/bitcoinschema/go-aip v0.0.0-20261018195243-19a9fa5df3ae
+	github.com/bitcoinschema/go-bob v0.5.2
+	github.com/bsv-blockchain/go-sdk v1.1.22
+	google.golang.org/grpc v1.80.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/bitcoinschema/go-bpu v0.2.2 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/crypto v0.47.0 // indirect
+	golang.org/x/net v0.49.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/text v0.33.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260120221211-b8f7ae30c516 // indirect
+)
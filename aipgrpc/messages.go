@@ -0,0 +1,33 @@
+package aipgrpc
+
+import (
+	"github.com/bitcoinschema/go-aip"
+)
+
+// NewAip will create a message from an AIP
+func NewAip(a *aip.Aip) *Aip {
+	m := &Aip{
+		Algorithm:                 string(a.Algorithm),
+		AlgorithmSigningComponent: a.AlgorithmSigningComponent,
+		Signature:                 a.Signature,
+		SignatureEncoding:         string(a.SignatureEncoding),
+	}
+	for _, d := range a.Data {
+		m.Data = append(m.Data, []byte(d))
+	}
+	return m
+}
+
+// Aip returns the AIP held by the message
+func (m *Aip) Aip() *aip.Aip {
+	a := &aip.Aip{
+		Algorithm:                 aip.Algorithm(m.Algorithm),
+		AlgorithmSigningComponent: m.AlgorithmSigningComponent,
+		Signature:                 m.Signature,
+		SignatureEncoding:         aip.SignatureEncoding(m.SignatureEncoding),
+	}
+	for _, d := range m.Data {
+		a.Data = append(a.Data, string(d))
+	}
+	return a
+}
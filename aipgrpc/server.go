@@ -0,0 +1,238 @@
+// Package aipgrpc is a gRPC service for signing and validating AIP signatures
+//
+// The service is defined in aip.proto, the Go messages and stubs are generated
+// with protoc-gen-go and protoc-gen-go-grpc and use the standard codec.
+package aipgrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative aip.proto
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bitcoinschema/go-aip"
+)
+
+// Authorizer decides whether the caller may sign with the server key
+//
+// It gets the call context (metadata, peer) and the full method name, and
+// returns an error to refuse. Errors that are not a gRPC status are reported
+// as PermissionDenied.
+type Authorizer func(ctx context.Context, fullMethod string) error
+
+// Server implements AipServiceServer
+//
+// Signing uses the key given to NewServer, so private keys never travel over
+// the wire. Invalid signatures are reported in the responses, only malformed
+// requests return an error status.
+type Server struct {
+	UnimplementedAipServiceServer
+	authorize  Authorizer
+	privateKey *ec.PrivateKey
+}
+
+var _ AipServiceServer = (*Server)(nil)
+
+// NewServer will create a new server signing with the private key (nil = signing disabled)
+//
+// The server key signs anything it is sent, so signing requires an authorizer:
+// every Sign and SignOpReturnData call is checked with it first.
+func NewServer(privateKey *ec.PrivateKey, authorize Authorizer) (*Server, error) {
+	if privateKey != nil && authorize == nil {
+		return nil, errors.New("an authorizer is required to enable signing")
+	}
+	return &Server{authorize: authorize, privateKey: privateKey}, nil
+}
+
+// Sign implements AipServiceServer
+func (s *Server) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
+	algorithm, err := s.signingAlgorithm(ctx, AipService_Sign_FullMethodName, req.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	var a *aip.Aip
+	if a, err = aip.Sign(s.privateKey, algorithm, string(req.Message)); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &SignResponse{Aip: NewAip(a)}, nil
+}
+
+// SignOpReturnData implements AipServiceServer
+func (s *Server) SignOpReturnData(ctx context.Context, req *SignOpReturnDataRequest) (*SignOpReturnDataResponse, error) {
+	algorithm, err := s.signingAlgorithm(ctx, AipService_SignOpReturnData_FullMethodName, req.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	data, a, err := aip.SignOpReturnData(s.privateKey, algorithm, req.Data)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &SignOpReturnDataResponse{Data: data, Aip: NewAip(a)}, nil
+}
+
+// Validate implements AipServiceServer
+func (s *Server) Validate(_ context.Context, req *ValidateRequest) (*ValidateResponse, error) {
+	if req.Aip == nil {
+		return nil, status.Error(codes.InvalidArgument, "aip is required")
+	}
+	return validate(req.Aip), nil
+}
+
+// ValidateTransaction implements AipServiceServer
+func (s *Server) ValidateTransaction(_ context.Context, req *ValidateTransactionRequest) (*ValidateTransactionResponse, error) {
+	mode, err := parseMode(req.Mode)
+	if err != nil {
+		return nil, err
+	}
+	var tx *transaction.Transaction
+	if tx, err = parseTx(req.RawTx); err != nil {
+		return nil, err
+	}
+	var results []*aip.StreamResult
+	if results, err = aip.ValidateTx(tx, mode); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp := &ValidateTransactionResponse{Txid: tx.TxID().String()}
+	for _, r := range results {
+		resp.Results = append(resp.Results, &TransactionResult{
+			Vout:      r.Vout,
+			Algorithm: string(r.Algorithm),
+			Signer:    r.Signer,
+			Valid:     r.Valid,
+			Error:     r.Error,
+		})
+	}
+	return resp, nil
+}
+
+// ExtractAll implements AipServiceServer
+func (s *Server) ExtractAll(_ context.Context, req *ExtractAllRequest) (*ExtractAllResponse, error) {
+	tx, err := parseTx(req.RawTx)
+	if err != nil {
+		return nil, err
+	}
+	var records []*aip.Record
+	if records, err = aip.NewRecordsFromTransaction(tx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp := &ExtractAllResponse{Txid: tx.TxID().String()}
+	for _, r := range records {
+		var a *aip.Aip
+		if a, err = r.Aip(); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Records = append(resp.Records, &Record{
+			Vout:      r.Vout,
+			TapeIndex: uint32(r.TapeIndex),
+			Aip:       NewAip(a),
+			Valid:     r.Valid,
+			Signer:    r.Signer,
+			Protocols: r.Protocols,
+		})
+	}
+	return resp, nil
+}
+
+// ValidateStream implements AipServiceServer
+func (s *Server) ValidateStream(stream grpc.BidiStreamingServer[ValidateRequest, ValidateResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		resp := &ValidateResponse{Error: "aip is required"}
+		if req.Aip != nil {
+			resp = validate(req.Aip)
+		}
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// ValidateTransactionStream implements AipServiceServer
+//
+// A transaction that cannot be read gets a response with Error set, the stream
+// goes on.
+func (s *Server) ValidateTransactionStream(
+	stream grpc.BidiStreamingServer[ValidateTransactionRequest, ValidateTransactionResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		resp, vErr := s.ValidateTransaction(stream.Context(), req)
+		if vErr != nil {
+			resp = &ValidateTransactionResponse{Error: status.Convert(vErr).Message()}
+		}
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// signingAlgorithm checks that signing is enabled for the caller and the algorithm is known (defaults to BitcoinSignedMessage)
+func (s *Server) signingAlgorithm(ctx context.Context, fullMethod, algorithm string) (aip.Algorithm, error) {
+	if s.privateKey == nil {
+		return "", status.Error(codes.FailedPrecondition, "signing is not enabled on this server")
+	}
+	if err := s.authorize(ctx, fullMethod); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return "", err
+		}
+		return "", status.Error(codes.PermissionDenied, err.Error())
+	}
+	switch a := aip.Algorithm(algorithm); a {
+	case "":
+		return aip.BitcoinSignedMessage, nil
+	case aip.BitcoinECDSA, aip.BitcoinSignedMessage, aip.Paymail:
+		return a, nil
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown algorithm: %s", algorithm)
+}
+
+// validate validates the AIP and returns the signer (the address, also for paymail)
+func validate(m *Aip) *ValidateResponse {
+	a := m.Aip()
+	valid, err := a.Validate()
+	if err != nil {
+		return &ValidateResponse{Error: err.Error()}
+	} else if !valid {
+		return &ValidateResponse{Error: "invalid signature"}
+	}
+	return &ValidateResponse{Valid: true, Signer: a.AlgorithmSigningComponent}
+}
+
+// parseMode returns the validation mode (defaults to strict)
+func parseMode(mode string) (aip.Mode, error) {
+	switch m := aip.Mode(mode); m {
+	case "":
+		return aip.ModeStrict, nil
+	case aip.ModeStrict, aip.ModeLegacy:
+		return m, nil
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown mode: %s", mode)
+}
+
+// parseTx parses a raw transaction
+func parseTx(raw []byte) (*transaction.Transaction, error) {
+	if len(raw) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "raw transaction is required")
+	}
+	tx, err := transaction.NewTransactionFromBytes(raw)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return tx, nil
+}
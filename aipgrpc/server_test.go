@@ -0,0 +1,375 @@
+package aipgrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bitcoinschema/go-aip"
+)
+
+// exampleSigner is the address of the example private key
+const exampleSigner = "1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK"
+
+var privBytes, _ = hex.DecodeString("54035dd4c7dda99ac473905a3d82f7864322b49bab1ff441cc457183b9bd8abd")
+var examplePrivateKey, _ = ec.PrivateKeyFromBytes(privBytes)
+
+// exampleToken is the bearer token accepted by the test authorizer
+const exampleToken = "secret"
+
+// tokenAuthorizer only accepts calls with the example bearer token
+func tokenAuthorizer(ctx context.Context, _ string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if tokens := md.Get("authorization"); len(tokens) != 1 || tokens[0] != "Bearer "+exampleToken {
+		return errors.New("invalid token")
+	}
+	return nil
+}
+
+// withToken returns the context with the example bearer token
+func withToken(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+exampleToken)
+}
+
+// newConn starts a server (AIP and health services) on an in-process bufconn listener and returns a connection
+func newConn(t testing.TB, privateKey *ec.PrivateKey) *grpc.ClientConn {
+	server, err := NewServer(privateKey, tokenAuthorizer)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterAipServiceServer(s, server)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go func() {
+		_ = s.Serve(lis)
+	}()
+
+	var conn *grpc.ClientConn
+	if conn, err = grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		s.Stop()
+	})
+	return conn
+}
+
+// newClient returns a client of a new server
+func newClient(t testing.TB, privateKey *ec.PrivateKey) AipServiceClient {
+	return NewAipServiceClient(newConn(t, privateKey))
+}
+
+// loadRawTxs returns the fixture transactions
+func loadRawTxs(t *testing.T) [][]byte {
+	raw, err := os.ReadFile("../testdata/txs.hex")
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var txs [][]byte
+	for _, line := range strings.Fields(string(raw)) {
+		var tx []byte
+		if tx, err = hex.DecodeString(line); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+// TestServer_Sign will test the methods Sign(), SignOpReturnData() and Validate()
+func TestServer_Sign(t *testing.T) {
+	t.Parallel()
+
+	ctx := withToken(context.Background())
+	client := newClient(t, examplePrivateKey)
+
+	for _, algorithm := range []aip.Algorithm{"", aip.BitcoinECDSA, aip.BitcoinSignedMessage, aip.Paymail} {
+		signed, err := client.Sign(ctx, &SignRequest{Algorithm: string(algorithm), Message: []byte("test message")})
+		if err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), algorithm, err.Error())
+		}
+		var validated *ValidateResponse
+		if validated, err = client.Validate(ctx, &ValidateRequest{Aip: signed.Aip}); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), algorithm, err.Error())
+		} else if !validated.Valid || validated.Signer != exampleSigner {
+			t.Errorf("%s Failed: [%s] expected signer [%s] but got [%v]", t.Name(), algorithm, exampleSigner, validated)
+		}
+
+		// Tampered data is reported as invalid (not as an error)
+		signed.Aip.Data[1] = []byte("other message")
+		if validated, err = client.Validate(ctx, &ValidateRequest{Aip: signed.Aip}); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), algorithm, err.Error())
+		} else if validated.Valid || validated.Error == "" {
+			t.Errorf("%s Failed: [%s] expected an invalid signature but got [%v]", t.Name(), algorithm, validated)
+		}
+	}
+
+	// Signed OP_RETURN data matches the library
+	data := [][]byte{[]byte("19HxigV4QyBv3tHpQVcUEQyq1pzZVdoAut"), []byte("hello world"), {0x00, 0xff}}
+	signed, err := client.SignOpReturnData(ctx, &SignOpReturnDataRequest{Data: data})
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	expected, _, _ := aip.SignOpReturnData(examplePrivateKey, aip.BitcoinSignedMessage, data)
+	if !reflect.DeepEqual(signed.Data, expected) {
+		t.Errorf("%s Failed: expected [%q] but got [%q]", t.Name(), expected, signed.Data)
+	}
+	if validated, _ := client.Validate(ctx, &ValidateRequest{Aip: signed.Aip}); !validated.Valid {
+		t.Errorf("%s Failed: signed data should be valid, error: %s", t.Name(), validated.Error)
+	}
+}
+
+// TestServer_Errors will test the error statuses of the server
+func TestServer_Errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := withToken(context.Background())
+	client := newClient(t, examplePrivateKey)
+	readOnly := newClient(t, nil)
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name     string
+			call     func() error
+			expected codes.Code
+		}{
+			{"unknown algorithm", func() error {
+				_, err := client.Sign(ctx, &SignRequest{Algorithm: "unknown"})
+				return err
+			}, codes.InvalidArgument},
+			{"signing disabled", func() error {
+				_, err := readOnly.Sign(ctx, &SignRequest{Message: []byte("test message")})
+				return err
+			}, codes.FailedPrecondition},
+			{"signing data disabled", func() error {
+				_, err := readOnly.SignOpReturnData(ctx, &SignOpReturnDataRequest{})
+				return err
+			}, codes.FailedPrecondition},
+			{"unauthorized sign", func() error {
+				_, err := client.Sign(context.Background(), &SignRequest{Message: []byte("test message")})
+				return err
+			}, codes.PermissionDenied},
+			{"unauthorized sign data", func() error {
+				_, err := client.SignOpReturnData(metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer other"),
+					&SignOpReturnDataRequest{Data: [][]byte{[]byte("hello")}})
+				return err
+			}, codes.PermissionDenied},
+			{"missing aip", func() error {
+				_, err := client.Validate(ctx, &ValidateRequest{})
+				return err
+			}, codes.InvalidArgument},
+			{"missing tx", func() error {
+				_, err := client.ValidateTransaction(ctx, &ValidateTransactionRequest{})
+				return err
+			}, codes.InvalidArgument},
+			{"invalid tx", func() error {
+				_, err := client.ExtractAll(ctx, &ExtractAllRequest{RawTx: []byte{0x01}})
+				return err
+			}, codes.InvalidArgument},
+			{"unknown mode", func() error {
+				_, err := client.ValidateTransaction(ctx, &ValidateTransactionRequest{RawTx: []byte{0x01}, Mode: "loose"})
+				return err
+			}, codes.InvalidArgument},
+		}
+	)
+
+	for _, test := range tests {
+		if code := status.Code(test.call()); code != test.expected {
+			t.Errorf("%s Failed: [%s] expected code [%s] but got [%s]", t.Name(), test.name, test.expected, code)
+		}
+	}
+
+	// Signing is only enabled with an authorizer
+	if _, err := NewServer(examplePrivateKey, nil); err == nil {
+		t.Errorf("%s Failed: error was expected (signing without an authorizer)", t.Name())
+	}
+	server, err := NewServer(examplePrivateKey, func(context.Context, string) error {
+		return status.Error(codes.Unauthenticated, "login required")
+	})
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	if _, err = server.Sign(ctx, &SignRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("%s Failed: expected the authorizer status but got [%v]", t.Name(), err)
+	}
+}
+
+// TestServer_ValidateTransaction will test the methods ValidateTransaction() and ExtractAll()
+func TestServer_ValidateTransaction(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newClient(t, nil)
+
+	for _, raw := range loadRawTxs(t) {
+		tx, err := transaction.NewTransactionFromBytes(raw)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		for _, mode := range []aip.Mode{aip.ModeStrict, aip.ModeLegacy} {
+			expected, _ := aip.ValidateTx(tx, mode)
+			resp, err := client.ValidateTransaction(ctx, &ValidateTransactionRequest{RawTx: raw, Mode: string(mode)})
+			if err != nil {
+				t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), mode, err.Error())
+			} else if resp.Txid != tx.TxID().String() || len(resp.Results) != len(expected) {
+				t.Fatalf("%s Failed: [%s] expected %d results for [%s] but got %d", t.Name(), mode, len(expected), tx.TxID(), len(resp.Results))
+			}
+			for i, r := range resp.Results {
+				if r.Vout != expected[i].Vout || r.Signer != expected[i].Signer || r.Valid != expected[i].Valid || r.Error != expected[i].Error {
+					t.Errorf("%s Failed: [%s] expected [%v] but got [%v]", t.Name(), mode, expected[i], r)
+				}
+			}
+		}
+
+		// Every AIP is extracted as a record
+		bobTx, _ := bob.NewFromTx(tx)
+		expected := aip.NewRecordsFromTx(&bobTx.Tx)
+		resp, err := client.ExtractAll(ctx, &ExtractAllRequest{RawTx: raw})
+		if err != nil {
+			t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+		} else if len(resp.Records) != len(expected) {
+			t.Fatalf("%s Failed: expected %d records but got %d", t.Name(), len(expected), len(resp.Records))
+		}
+		for i, r := range resp.Records {
+			a, _ := expected[i].Aip()
+			if r.Signer != expected[i].Signer || r.Valid != expected[i].Valid ||
+				int(r.TapeIndex) != expected[i].TapeIndex || !reflect.DeepEqual(r.Aip.Aip(), a) ||
+				!reflect.DeepEqual(r.Protocols, expected[i].Protocols) {
+				t.Errorf("%s Failed: expected [%v] but got [%v]", t.Name(), expected[i], r)
+			}
+		}
+	}
+}
+
+// TestServer_Streams will test the methods ValidateStream() and ValidateTransactionStream()
+func TestServer_Streams(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newClient(t, nil)
+
+	a, _ := aip.Sign(examplePrivateKey, aip.BitcoinSignedMessage, "test message")
+	tampered := NewAip(a)
+	tampered.Data[1] = []byte("other message")
+	requests := []*ValidateRequest{{Aip: NewAip(a)}, {Aip: tampered}, {}, {Aip: NewAip(a)}}
+	expected := []bool{true, false, false, true}
+
+	stream, err := client.ValidateStream(ctx)
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	for _, req := range requests {
+		if err = stream.Send(req); err != nil {
+			t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+		}
+	}
+	_ = stream.CloseSend()
+	for i := range requests {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+		} else if resp.Valid != expected[i] {
+			t.Errorf("%s Failed: [%d] expected valid [%t] but got [%v]", t.Name(), i, expected[i], resp)
+		}
+	}
+
+	// Unreadable transactions do not end the stream
+	raws := append([][]byte{{0x01}}, loadRawTxs(t)...)
+	txStream, err := client.ValidateTransactionStream(ctx)
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	for _, raw := range raws {
+		if err = txStream.Send(&ValidateTransactionRequest{RawTx: raw}); err != nil {
+			t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+		}
+	}
+	_ = txStream.CloseSend()
+	for i, raw := range raws {
+		resp, err := txStream.Recv()
+		if err != nil {
+			t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+		}
+		if i == 0 {
+			if resp.Error == "" {
+				t.Errorf("%s Failed: expected an error for an unreadable transaction", t.Name())
+			}
+			continue
+		}
+		tx, _ := transaction.NewTransactionFromBytes(raw)
+		if resp.Error != "" || resp.Txid != tx.TxID().String() {
+			t.Errorf("%s Failed: expected txid [%s] but got [%v]", t.Name(), tx.TxID(), resp)
+		}
+	}
+}
+
+// TestMessages will test the protobuf encoding of the generated messages
+func TestMessages(t *testing.T) {
+	t.Parallel()
+
+	// Known encoding: field 1 (string "BITCOIN_ECDSA"), field 3 (bytes "j")
+	b, err := proto.Marshal(&Aip{Algorithm: "BITCOIN_ECDSA", Data: [][]byte{[]byte("j")}})
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if expected := append([]byte{0x0a, 0x0d}, append([]byte("BITCOIN_ECDSA"), 0x1a, 0x01, 'j')...); !bytes.Equal(b, expected) {
+		t.Errorf("%s Failed: expected [%x] but got [%x]", t.Name(), expected, b)
+	}
+
+	// The AIP survives the conversion to the message and back
+	a, _ := aip.Sign(examplePrivateKey, aip.Paymail, "test message")
+	m := new(Aip)
+	if b, err = proto.Marshal(NewAip(a)); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if err = proto.Unmarshal(b, m); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if !reflect.DeepEqual(m.Aip(), a) {
+		t.Errorf("%s Failed: expected [%v] but got [%v]", t.Name(), a, m.Aip())
+	}
+}
+
+// TestServer_SharedServer will test that other services on the same server keep their codec
+func TestServer_SharedServer(t *testing.T) {
+	t.Parallel()
+
+	resp, err := healthpb.NewHealthClient(newConn(t, nil)).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("%s Failed: expected [SERVING] but got [%s]", t.Name(), resp.GetStatus())
+	}
+}
+
+// BenchmarkServer_Validate benchmarks the method Validate() over bufconn
+func BenchmarkServer_Validate(b *testing.B) {
+	client := newClient(b, nil)
+	a, _ := aip.Sign(examplePrivateKey, aip.BitcoinSignedMessage, "test message")
+	req := &ValidateRequest{Aip: NewAip(a)}
+	for i := 0; i < b.N; i++ {
+		_, _ = client.Validate(context.Background(), req)
+	}
+}
@@ -0,0 +1,26 @@
+module github.com/bitcoinschema/go-aip/aipotel
+
+go 1.24.1
+
+require (
+	github.com/bitcoinschema/go-aip v0.0.0-20261018195243-19a9fa5df3ae
+	github.com/bitcoinschema/go-bob v0.5.2
+	go.opentelemetry.io/otel v1.40.0
+	go.opentelemetry.io/otel/metric v1.40.0
+	go.opentelemetry.io/otel/sdk v1.40.0
+	go.opentelemetry.io/otel/sdk/metric v1.40.0
+	go.opentelemetry.io/otel/trace v1.40.0
+)
+
+require (
+	github.com/bitcoinschema/go-bpu v0.2.2 // indirect
+	github.com/bsv-blockchain/go-sdk v1.1.22 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	golang.org/x/crypto v0.47.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+)
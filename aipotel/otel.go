@@ -0,0 +1,132 @@
+// Package aipotel reports AIP validations as OpenTelemetry spans and metrics
+//
+// It only uses the OpenTelemetry API: spans and metrics go to whatever
+// providers are configured (the global ones by default), so the exporter is
+// chosen by the application. Install it with aip.SetObserver or per call with
+// aip.ValidateOptions.Observer.
+//
+// Metrics:
+//
+//	aip.validations          counter    algorithm, mode, valid
+//	aip.validation.failures  counter    algorithm, mode, reason
+//	aip.validation.duration  histogram  algorithm, mode, valid (seconds)
+//	aip.stage.duration       histogram  stage, error (seconds)
+package aipotel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bitcoinschema/go-aip"
+)
+
+// instrumentationName is the name of the tracer and meter
+const instrumentationName = "github.com/bitcoinschema/go-aip"
+
+// Attribute keys
+const (
+	AlgorithmKey = attribute.Key("aip.algorithm") // The known algorithm (or "unknown")
+	ErrorKey     = attribute.Key("aip.error")     // True if the stage failed
+	ModeKey      = attribute.Key("aip.mode")      // The validation mode
+	ReasonKey    = attribute.Key("aip.reason")    // Why the validation failed
+	StageKey     = attribute.Key("aip.stage")     // The validation stage
+	ValidKey     = attribute.Key("aip.valid")     // True if the signature is valid
+)
+
+// Options are the options used by New
+type Options struct {
+	TracerProvider trace.TracerProvider // Defaults to the global tracer provider
+	MeterProvider  metric.MeterProvider // Defaults to the global meter provider
+}
+
+// Observer is an aip.Observer recording spans and metrics
+type Observer struct {
+	tracer        trace.Tracer
+	validations   metric.Int64Counter
+	failures      metric.Int64Counter
+	duration      metric.Float64Histogram
+	stageDuration metric.Float64Histogram
+}
+
+var _ aip.Observer = (*Observer)(nil)
+
+// New will create a new observer and its instruments
+func New(opts *Options) (*Observer, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	tp, mp := opts.TracerProvider, opts.MeterProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(instrumentationName)
+
+	o := &Observer{tracer: tp.Tracer(instrumentationName)}
+	var err error
+	if o.validations, err = meter.Int64Counter("aip.validations",
+		metric.WithDescription("AIP validations"), metric.WithUnit("{validation}")); err != nil {
+		return nil, err
+	}
+	if o.failures, err = meter.Int64Counter("aip.validation.failures",
+		metric.WithDescription("Failed AIP validations by reason"), metric.WithUnit("{validation}")); err != nil {
+		return nil, err
+	}
+	if o.duration, err = meter.Float64Histogram("aip.validation.duration",
+		metric.WithDescription("Duration of AIP validations"), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if o.stageDuration, err = meter.Float64Histogram("aip.stage.duration",
+		metric.WithDescription("Duration of AIP validation stages"), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// StartValidation implements aip.Observer, the validation is a span with a child span per stage
+func (o *Observer) StartValidation(ctx context.Context) (context.Context, func(aip.ValidationEvent)) {
+	ctx, span := o.tracer.Start(ctx, "aip.validate")
+	return ctx, func(event aip.ValidationEvent) {
+		attrs := []attribute.KeyValue{
+			AlgorithmKey.String(string(event.Algorithm)),
+			ModeKey.String(string(event.Mode)),
+		}
+		set := metric.WithAttributes(append(attrs, ValidKey.Bool(event.Valid))...)
+		o.validations.Add(ctx, 1, set)
+		o.duration.Record(ctx, event.Duration.Seconds(), set)
+		if event.Reason != aip.FailureNone {
+			o.failures.Add(ctx, 1, metric.WithAttributes(append(attrs, ReasonKey.String(string(event.Reason)))...))
+			attrs = append(attrs, ReasonKey.String(string(event.Reason)))
+		}
+
+		span.SetAttributes(append(attrs, ValidKey.Bool(event.Valid))...)
+		if event.Err != nil {
+			span.RecordError(event.Err)
+			span.SetStatus(codes.Error, event.Err.Error())
+		}
+		span.End()
+	}
+}
+
+// StartStage implements aip.Observer
+func (o *Observer) StartStage(ctx context.Context, stage aip.Stage) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := o.tracer.Start(ctx, "aip."+string(stage))
+	return ctx, func(err error) {
+		o.stageDuration.Record(ctx, time.Since(start).Seconds(),
+			metric.WithAttributes(StageKey.String(string(stage)), ErrorKey.Bool(err != nil)))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
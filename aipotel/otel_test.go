@@ -0,0 +1,142 @@
+package aipotel
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/bitcoinschema/go-aip"
+)
+
+// validateFixtures validates every AIP in the fixture transactions and returns the valid and invalid counts
+func validateFixtures(t *testing.T, o aip.Observer) (valid, invalid int64) {
+	raw, err := os.ReadFile("../testdata/txs.hex")
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	for _, line := range strings.Fields(string(raw)) {
+		var bobTx *bob.Tx
+		if bobTx, err = bob.NewFromRawTxString(line); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		for _, out := range bobTx.Out {
+			for instance := range aip.NewFromAllTapes(out.Tape) {
+				result, _ := aip.ValidateTapesWithContext(context.Background(), out.Tape,
+					&aip.ValidateOptions{Instance: instance, Observer: o})
+				if result != nil && result.Valid {
+					valid++
+				} else {
+					invalid++
+				}
+			}
+		}
+	}
+	return valid, invalid
+}
+
+// sum returns the total of an Int64 sum metric
+func sum(rm *metricdata.ResourceMetrics, name string) (total int64) {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if data, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == name {
+				for _, point := range data.DataPoints {
+					total += point.Value
+				}
+			}
+		}
+	}
+	return total
+}
+
+// count returns the number of observations of a Float64 histogram metric
+func count(rm *metricdata.ResourceMetrics, name string) (total uint64) {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if data, ok := m.Data.(metricdata.Histogram[float64]); ok && m.Name == name {
+				for _, point := range data.DataPoints {
+					total += point.Count
+				}
+			}
+		}
+	}
+	return total
+}
+
+// TestObserver will test the spans and metrics recorded by the Observer
+func TestObserver(t *testing.T) {
+	t.Parallel()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	o, err := New(&Options{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	valid, invalid := validateFixtures(t, o)
+	if valid == 0 || invalid == 0 {
+		t.Fatalf("%s Failed: expected valid and invalid fixtures but got %d and %d", t.Name(), valid, invalid)
+	}
+
+	// Metrics
+	var rm metricdata.ResourceMetrics
+	if err = reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	if total := sum(&rm, "aip.validations"); total != valid+invalid {
+		t.Errorf("%s Failed: expected %d validations but got %d", t.Name(), valid+invalid, total)
+	}
+	if failures := sum(&rm, "aip.validation.failures"); failures != invalid {
+		t.Errorf("%s Failed: expected %d failures but got %d", t.Name(), invalid, failures)
+	}
+	if observations := count(&rm, "aip.validation.duration"); observations != uint64(valid+invalid) {
+		t.Errorf("%s Failed: expected %d durations but got %d", t.Name(), valid+invalid, observations)
+	}
+	if observations := count(&rm, "aip.stage.duration"); observations < uint64(4*valid) {
+		t.Errorf("%s Failed: expected at least %d stage durations but got %d", t.Name(), 4*valid, observations)
+	}
+
+	// Spans: one per validation, stages are its children
+	roots := make(map[string]bool)
+	var stages int
+	for _, span := range spans.Ended() {
+		if span.Name() == "aip.validate" {
+			roots[span.SpanContext().SpanID().String()] = true
+		}
+	}
+	for _, span := range spans.Ended() {
+		if span.Name() == "aip.validate" {
+			continue
+		}
+		stages++
+		if !roots[span.Parent().SpanID().String()] {
+			t.Errorf("%s Failed: stage span [%s] is not a child of a validation span", t.Name(), span.Name())
+		}
+	}
+	if int64(len(roots)) != valid+invalid || stages == 0 {
+		t.Errorf("%s Failed: expected %d validation spans but got %d (and %d stage spans)", t.Name(), valid+invalid, len(roots), stages)
+	}
+}
+
+// TestNew will test the method New() with the global (no-op) providers
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	o, err := New(nil)
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	if valid, _ := validateFixtures(t, o); valid == 0 {
+		t.Errorf("%s Failed: expected valid fixtures", t.Name())
+	}
+}
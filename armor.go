@@ -0,0 +1,182 @@
+package aip
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// Armor lines of a signed message
+const (
+	armorBeginMessage   = "-----BEGIN AIP SIGNED MESSAGE-----"
+	armorBeginSignature = "-----BEGIN AIP SIGNATURE-----"
+	armorEndSignature   = "-----END AIP SIGNATURE-----"
+)
+
+// CanonicalMessage returns the form of the message that survives copy and paste
+//
+// Line endings become "\n", trailing spaces and tabs are removed from every
+// line and trailing blank lines are dropped. Armored messages are signed and
+// verified in this form.
+func CanonicalMessage(message string) string {
+	lines := strings.Split(normalizeLineEndings(message), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// SignArmored signs the canonical form of the message and returns it armored
+func SignArmored(privateKey *ec.PrivateKey, algorithm Algorithm, message string) (string, *Aip, error) {
+	a, err := Sign(privateKey, algorithm, CanonicalMessage(message))
+	if err != nil {
+		return "", nil, err
+	}
+	var armored string
+	if armored, err = Armor(a); err != nil {
+		return "", nil, err
+	}
+	return armored, a, nil
+}
+
+// Armor returns the signed message (made by Sign) as a clear-signed text block
+//
+//	-----BEGIN AIP SIGNED MESSAGE-----
+//	<message, lines starting with "-" are escaped with "- ">
+//	-----BEGIN AIP SIGNATURE-----
+//	Algorithm: <algorithm>
+//	Signing-Component: <address or identity key>
+//	Signature: <base64>
+//	-----END AIP SIGNATURE-----
+//
+// The message must be canonical (see CanonicalMessage), otherwise it could not be
+// verified after transport.
+func Armor(a *Aip) (string, error) {
+	if a == nil || len(a.Data) == 0 || a.Data[0] != opReturn {
+		return "", errors.New("the first item in payload is always OP_RETURN")
+	} else if len(a.Algorithm) == 0 || len(a.AlgorithmSigningComponent) == 0 || len(a.Signature) == 0 {
+		return "", errors.New("missing algorithm, signing component or signature")
+	}
+	message := strings.Join(a.Data[1:], "")
+	if CanonicalMessage(message) != message {
+		return "", errors.New("message is not canonical, sign it with SignArmored")
+	}
+
+	var b strings.Builder
+	b.WriteString(armorBeginMessage + "\n")
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, " \t"), "-") {
+			b.WriteString("- ")
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(armorBeginSignature + "\n")
+	b.WriteString("Algorithm: " + string(a.Algorithm) + "\n")
+	b.WriteString("Signing-Component: " + a.AlgorithmSigningComponent + "\n")
+	b.WriteString("Signature: " + a.Signature + "\n")
+	b.WriteString(armorEndSignature + "\n")
+	return b.String(), nil
+}
+
+// Dearmor parses a clear-signed text block into an AIP, ready to Validate
+//
+// Parsing is strict (only whitespace may surround the block, every header is
+// required once) but ignores line endings, trailing whitespace and indentation
+// of the armor lines.
+func Dearmor(armored string) (*Aip, error) {
+	lines, err := armorLines(armored, armorBeginMessage, armorEndSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	// The message ends at the (unescaped) signature line
+	split := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == armorBeginSignature {
+			split = i
+			break
+		}
+	}
+	if split < 0 {
+		return nil, errors.New("armor signature line not found")
+	}
+	message := make([]string, split)
+	for i, line := range lines[:split] {
+		if strings.HasPrefix(strings.TrimLeft(line, " \t"), "-") {
+			if !strings.HasPrefix(line, "- ") {
+				return nil, fmt.Errorf("line %d is not dash-escaped", i+1)
+			}
+			line = line[2:]
+		}
+		message[i] = line
+	}
+
+	a := new(Aip)
+	if err = armorHeaders(lines[split+1:], map[string]*string{
+		"Algorithm":         (*string)(&a.Algorithm),
+		"Signing-Component": &a.AlgorithmSigningComponent,
+		"Signature":         &a.Signature,
+	}); err != nil {
+		return nil, err
+	}
+	a.Data = []string{opReturn, CanonicalMessage(strings.Join(message, "\n"))}
+	return a, nil
+}
+
+// armorLines returns the lines between the begin and end lines
+//
+// Only whitespace may appear around the block. Line endings are normalized.
+func armorLines(armored, begin, end string) ([]string, error) {
+	lines := strings.Split(normalizeLineEndings(armored), "\n")
+	first, last := -1, -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case first < 0 && trimmed == begin:
+			first = i
+		case first < 0 && len(trimmed) > 0:
+			return nil, errors.New("armor begin line not found")
+		case first >= 0 && last < 0 && trimmed == end:
+			last = i
+		case last >= 0 && len(trimmed) > 0:
+			return nil, errors.New("unexpected text after the armor")
+		}
+	}
+	if first < 0 {
+		return nil, errors.New("armor begin line not found")
+	} else if last < 0 {
+		return nil, errors.New("armor end line not found")
+	}
+	return lines[first+1 : last], nil
+}
+
+// armorHeaders parses "Key: value" lines into the fields, every field is required once
+func armorHeaders(lines []string, fields map[string]*string) error {
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		field, known := fields[strings.TrimSpace(key)]
+		if !found || !known {
+			return fmt.Errorf("unknown armor header: %s", line)
+		} else if len(*field) > 0 {
+			return fmt.Errorf("duplicate armor header: %s", key)
+		}
+		*field = strings.TrimSpace(value)
+	}
+	for key, field := range fields {
+		if len(*field) == 0 {
+			return fmt.Errorf("missing armor header: %s", key)
+		}
+	}
+	return nil
+}
+
+// normalizeLineEndings converts "\r\n" and "\r" line endings to "\n"
+func normalizeLineEndings(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n")
+}
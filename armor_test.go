@@ -0,0 +1,175 @@
+package aip
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// armorVector is a clear-signed message and its expected verification
+type armorVector struct {
+	Description string `json:"description"`
+	Armored     string `json:"armored"`
+	Valid       bool   `json:"valid"`
+	Message     string `json:"message,omitempty"` // Set when the armor parses
+	Signer      string `json:"signer,omitempty"`
+}
+
+// loadArmorVectors loads the armor test vectors or fails the test
+func loadArmorVectors(t *testing.T) []armorVector {
+	raw, err := os.ReadFile("testdata/armor.json")
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var vectors []armorVector
+	if err = json.Unmarshal(raw, &vectors); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return vectors
+}
+
+// TestDearmor will test the method Dearmor() against the test vectors
+func TestDearmor(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range loadArmorVectors(t) {
+		a, err := Dearmor(v.Armored)
+		if err != nil {
+			if v.Valid || len(v.Message) > 0 {
+				t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), v.Description, err.Error())
+			}
+			continue
+		}
+		if len(v.Message) > 0 && a.Data[1] != v.Message {
+			t.Errorf("%s Failed: [%s] expected message [%q] but got [%q]", t.Name(), v.Description, v.Message, a.Data[1])
+		}
+		if !v.Valid {
+			if valid, _ := a.Validate(); valid {
+				t.Errorf("%s Failed: [%s] should not be valid", t.Name(), v.Description)
+			}
+		} else if valid, err := a.ValidateSigner(v.Signer); !valid {
+			t.Errorf("%s Failed: [%s] expected signer [%s], error: %v", t.Name(), v.Description, v.Signer, err)
+		}
+	}
+}
+
+// TestArmor will test the methods Armor() and SignArmored()
+func TestArmor(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range loadArmorVectors(t)[:3] {
+		a, err := Dearmor(v.Armored)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		// Re-armoring a parsed vector gives the same text
+		var armored string
+		if armored, err = Armor(a); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), v.Description, err.Error())
+		} else if armored != v.Armored {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%s]", t.Name(), v.Description, v.Armored, armored)
+		}
+	}
+
+	// Messages are signed in canonical form
+	armored, a, err := SignArmored(examplePrivateKey, BitcoinSignedMessage, "line one  \r\nline two\t\r\n\r\n")
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if a.Data[1] != "line one\nline two" {
+		t.Errorf("%s Failed: expected canonical message but got [%q]", t.Name(), a.Data[1])
+	}
+	var parsed *Aip
+	if parsed, err = Dearmor(armored); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if valid, err := parsed.Validate(); !valid {
+		t.Errorf("%s Failed: signed message should be valid, error: %v", t.Name(), err)
+	}
+
+	// Non-canonical or incomplete AIPs cannot be armored
+	notCanonical, _ := Sign(examplePrivateKey, BitcoinSignedMessage, "trailing space ")
+	for _, a := range []*Aip{
+		nil,
+		{},
+		notCanonical,
+		{Algorithm: BitcoinSignedMessage, Data: []string{opReturn, "message"}},
+		{Algorithm: BitcoinSignedMessage, AlgorithmSigningComponent: exampleAddress, Data: []string{"message"}, Signature: "sig"},
+	} {
+		if _, err = Armor(a); err == nil {
+			t.Errorf("%s Failed: [%v] inputted and error was expected", t.Name(), a)
+		}
+	}
+	if _, _, err = SignArmored(examplePrivateKey, "unknown", "message"); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown algorithm)", t.Name())
+	}
+}
+
+// TestCanonicalMessage will test the method CanonicalMessage()
+func TestCanonicalMessage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			input    string
+			expected string
+		}{
+			{"", ""},
+			{"hello", "hello"},
+			{"hello\r\nworld\r\n", "hello\nworld"},
+			{"hello\rworld", "hello\nworld"},
+			{"hello \t\nworld  ", "hello\nworld"},
+			{"\n\n  indented\n\n\n", "\n\n  indented"},
+		}
+	)
+
+	for _, test := range tests {
+		if output := CanonicalMessage(test.input); output != test.expected {
+			t.Errorf("%s Failed: [%q] inputted and [%q] expected, received: [%q]", t.Name(), test.input, test.expected, output)
+		}
+	}
+}
+
+// ExampleSignArmored example using SignArmored()
+func ExampleSignArmored() {
+	armored, _, err := SignArmored(examplePrivateKey, BitcoinSignedMessage, "I wrote this.")
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Print(armored)
+	// Output:-----BEGIN AIP SIGNED MESSAGE-----
+	// I wrote this.
+	// -----BEGIN AIP SIGNATURE-----
+	// Algorithm: BitcoinSignedMessage
+	// Signing-Component: 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK
+	// Signature: Hykk+Km6lItivIN7wtaboMnqWXduARUIP59mNwMAnahyU1P4v1eSqpr26wucGMpuqwk/l1vnD0ay5i6RiISEEWs=
+	// -----END AIP SIGNATURE-----
+}
+
+// ExampleDearmor example using Dearmor()
+func ExampleDearmor() {
+	a, err := Dearmor("-----BEGIN AIP SIGNED MESSAGE-----\r\n" +
+		"I wrote this.\r\n" +
+		"-----BEGIN AIP SIGNATURE-----\r\n" +
+		"Algorithm: BitcoinSignedMessage\r\n" +
+		"Signing-Component: 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK\r\n" +
+		"Signature: Hykk+Km6lItivIN7wtaboMnqWXduARUIP59mNwMAnahyU1P4v1eSqpr26wucGMpuqwk/l1vnD0ay5i6RiISEEWs=\r\n" +
+		"-----END AIP SIGNATURE-----\r\n")
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	valid, _ := a.Validate()
+	fmt.Printf("message: %s valid: %t", a.Data[1], valid)
+	// Output:message: I wrote this. valid: true
+}
+
+// BenchmarkDearmor benchmarks the method Dearmor()
+func BenchmarkDearmor(b *testing.B) {
+	armored, _, _ := SignArmored(examplePrivateKey, BitcoinSignedMessage, "I wrote this.")
+	for i := 0; i < b.N; i++ {
+		_, _ = Dearmor(armored)
+	}
+}
@@ -0,0 +1,110 @@
+// Package bitcom splits a script into Bitcom protocol segments without BOB
+//
+// The split follows BOB (go-bob NewFromTx): everything up to and including the
+// first OP_RETURN is the first segment, and after an OP_RETURN every pipe (a
+// "|" push or the 0x7c opcode) starts a new segment. Separators are dropped and
+// empty segments are never returned. Unlike BOB's shallow mode, scripts with
+// more than 255 chunks are not truncated. The package only depends on the
+// go-sdk script package.
+package bitcom
+
+import (
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// Pipe is the Bitcom protocol separator
+const Pipe = "|"
+
+// Cell is a chunk of a segment: a data push or an opcode
+type Cell struct {
+	Op    byte   // The opcode, or the push opcode for data
+	Data  []byte // The pushed data (empty for opcodes)
+	Index int    // Position of the chunk in the script
+}
+
+// IsOpcode returns true if the cell is an opcode rather than a data push (OP_0 is an opcode)
+func (c Cell) IsOpcode() bool {
+	return c.Op == script.Op0 || c.Op > script.OpPUSHDATA4
+}
+
+// Segment is a protocol segment: the cells between two separators
+type Segment struct {
+	Cells []Cell
+}
+
+// Prefix returns the protocol prefix (the first data push), or "" if there is none
+func (s *Segment) Prefix() string {
+	for _, c := range s.Cells {
+		if !c.IsOpcode() {
+			return string(c.Data)
+		}
+	}
+	return ""
+}
+
+// Pushes returns the data pushed in the segment
+func (s *Segment) Pushes() [][]byte {
+	pushes := make([][]byte, 0, len(s.Cells))
+	for _, c := range s.Cells {
+		if !c.IsOpcode() {
+			pushes = append(pushes, c.Data)
+		}
+	}
+	return pushes
+}
+
+// Split returns the protocol segments of the script (nil for an empty script)
+func Split(s *script.Script) ([]Segment, error) {
+	if s == nil {
+		return nil, nil
+	}
+	chunks, err := s.Chunks()
+	if err != nil {
+		return nil, err
+	}
+	return SplitChunks(chunks), nil
+}
+
+// SplitChunks returns the protocol segments of the decoded script chunks
+func SplitChunks(chunks []*script.ScriptChunk) []Segment {
+	var segments []Segment
+	current := 0
+	var opReturned, split bool
+	for i, chunk := range chunks {
+
+		// The cell after a separator goes in the next segment
+		if split && len(segments) > current {
+			current++
+		}
+		cell := Cell{Op: chunk.Op, Data: chunk.Data, Index: i}
+
+		// OP_RETURN ends its segment, pipes (OP_SWAP is the "|" byte) only separate after an OP_RETURN
+		var include bool
+		if cell.IsOpcode() {
+			include = chunk.Op == script.OpRETURN
+			split = include || (opReturned && chunk.Op == script.OpSWAP)
+		} else {
+			split = opReturned && string(chunk.Data) == Pipe
+		}
+
+		switch {
+		case !split:
+			if len(segments) == current {
+				segments = append(segments, Segment{})
+			}
+			segments[current].Cells = append(segments[current].Cells, cell)
+		case include:
+			if len(segments) == 0 {
+				segments = append(segments, Segment{})
+			}
+			last := &segments[len(segments)-1]
+			last.Cells = append(last.Cells, cell)
+		}
+
+		// A pushed OP_RETURN byte also enables the pipes (as in BOB)
+		if chunk.Op == script.OpRETURN || (len(chunk.Data) == 1 && chunk.Data[0] == script.OpRETURN) {
+			opReturned = true
+		}
+	}
+	return segments
+}
@@ -0,0 +1,84 @@
+package bitcom
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// TestSplit will test the method Split()
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name             string
+			inputASM         string
+			expectedPrefixes string // Prefix of each segment ("-" = none)
+			expectedCells    string // Number of cells of each segment
+		}{
+			{"empty", "", "", ""},
+			{"op_return", "OP_FALSE OP_RETURN 6869", "- hi", "2 1"},
+			{"pipes", "OP_FALSE OP_RETURN 61 7c 62 7c 63", "- a b c", "2 1 1 1"},
+			{"consecutive pipes", "OP_FALSE OP_RETURN 61 7c 7c 62 7c", "- a b", "2 1 1"},
+			{"pipe before op_return", "61 7c OP_RETURN 62", "a b", "3 1"},
+			{"pipe opcode", "OP_RETURN 61 OP_SWAP 62", "- a b", "1 1 1"},
+			{"pushed op_return", "6a 61 7c 62", "j b", "2 1"},
+			{"p2pkh prefix", "OP_DUP OP_HASH160 6161 OP_EQUALVERIFY OP_CHECKSIG OP_RETURN 61", "aa a", "6 1"},
+		}
+	)
+
+	for _, test := range tests {
+		s, err := script.NewFromASM(test.inputASM)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		var segments []Segment
+		if segments, err = Split(s); err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		}
+		var prefixes, cells []string
+		for _, segment := range segments {
+			prefix := segment.Prefix()
+			if prefix == "" {
+				prefix = "-"
+			}
+			prefixes = append(prefixes, prefix)
+			cells = append(cells, fmt.Sprint(len(segment.Cells)))
+		}
+		if strings.Join(prefixes, " ") != test.expectedPrefixes || strings.Join(cells, " ") != test.expectedCells {
+			t.Errorf("%s Failed: [%s] expected [%s] [%s] but got [%s] [%s]", t.Name(), test.name,
+				test.expectedPrefixes, test.expectedCells, strings.Join(prefixes, " "), strings.Join(cells, " "))
+		}
+	}
+
+	// Truncated pushes and nil scripts
+	if _, err := Split(script.NewFromBytes([]byte{script.OpRETURN, 0x05, 'h'})); err == nil {
+		t.Errorf("%s Failed: error was expected (truncated push)", t.Name())
+	}
+	if segments, err := Split(nil); err != nil || segments != nil {
+		t.Errorf("%s Failed: expected no segments but got [%v] [%v]", t.Name(), segments, err)
+	}
+}
+
+// ExampleSplit example using Split()
+func ExampleSplit() {
+	s, _ := script.NewFromASM("OP_FALSE OP_RETURN 31394878696756345179427633744870515663554551797131707a5a56646f417574 68656c6c6f 7c 313550636948473232534e4c514a584d6f5355615756693757537163376843667661")
+	segments, _ := Split(s)
+	for _, segment := range segments[1:] {
+		fmt.Printf("%s (%d pushes)\n", segment.Prefix(), len(segment.Pushes()))
+	}
+	// Output:19HxigV4QyBv3tHpQVcUEQyq1pzZVdoAut (2 pushes)
+	// 15PciHG22SNLQJXMoSUaWVi7WSqc7hCfva (1 pushes)
+}
+
+// BenchmarkSplit benchmarks the method Split()
+func BenchmarkSplit(b *testing.B) {
+	s, _ := script.NewFromASM("OP_FALSE OP_RETURN 31394878696756345179427633744870515663554551797131707a5a56646f417574 68656c6c6f 7c 313550636948473232534e4c514a584d6f5355615756693757537163376843667661")
+	for i := 0; i < b.N; i++ {
+		_, _ = Split(s)
+	}
+}
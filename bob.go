@@ -31,14 +31,14 @@ func (a *Aip) FromTape(tape bpu.Tape) {
 	var startIndex int
 	found := false
 	for i, cell := range tape.Cell {
-		if *cell.S == Prefix {
+		if cell.S != nil && *cell.S == Prefix {
 			startIndex = i
 			found = true
 			break
 		}
 	}
 
-	if !found {
+	if !found || len(tape.Cell) < startIndex+4 {
 		return
 	}
 	// Set the AIP fields
@@ -48,8 +48,8 @@ func (a *Aip) FromTape(tape bpu.Tape) {
 	if tape.Cell[startIndex+2].S != nil {
 		a.AlgorithmSigningComponent = *tape.Cell[startIndex+2].S
 	}
-	if tape.Cell[startIndex+3].B != nil {
-		a.Signature = *tape.Cell[startIndex+3].B
+	if sig, encoding, ok := signatureFromCell(tape.Cell[startIndex+3]); ok {
+		a.Signature, a.SignatureEncoding = sig, encoding
 	}
 
 	// Final index count
@@ -91,6 +91,27 @@ func NewFromTapes(tapes []bpu.Tape) (a *Aip) {
 
 // SetDataFromTapes sets the data the AIP signature is signing
 func (a *Aip) SetDataFromTapes(tapes []bpu.Tape, instance int) {
+	if data, found := a.dataFromTapes(tapes, instance, defaultReconstruction); found {
+		a.Data = data
+	}
+}
+
+// reconstruction describes how the signed payload is rebuilt from tapes
+type reconstruction struct {
+	trim     bool // Trim whitespace from string cells
+	pipes    bool // Insert a pipe when the AIP prefix is reached
+	allPipes bool // Also insert the pipes between protocols (signed by bytes.Join payloads)
+	envelope bool // Indices leave out the cells before the envelope start (always for inscriptions)
+}
+
+// defaultReconstruction is the interpretation used by SetDataFromTapes
+var defaultReconstruction = reconstruction{trim: true, pipes: true}
+
+// specReconstruction is the exact payload of the spec (cells are not trimmed)
+var specReconstruction = reconstruction{trim: false, pipes: true}
+
+// dataFromTapes rebuilds the data signed by the given AIP instance
+func (a *Aip) dataFromTapes(tapes []bpu.Tape, instance int, r reconstruction) ([]string, bool) {
 	// Set OP_RETURN to be consistent with BitcoinFiles SDK
 	// var data [][]byte
 	var data = []string{opReturn}
@@ -119,51 +140,84 @@ func (a *Aip) SetDataFromTapes(tapes []bpu.Tape, instance int) {
 	}
 
 	// If we found AIP, collect data from all tapes up to the AIP tape
-	if foundAIP {
-		if len(a.Indices) == 0 {
+	if !foundAIP {
+		return nil, false
+	}
+	// Only data after the OP_RETURN of the envelope is signed
+	envelope, startTape, startCell := envelopeStart(tapes)
+	if len(a.Indices) == 0 {
 
-			// Walk over all output values and concatenate them until we hit the AIP prefix, then add in the separator
-			for i, tape := range tapes {
-				for j, cell := range tape.Cell {
+		// Walk over all output values and concatenate them until we hit the AIP prefix, then add in the separator
+		var piped bool
+		for i, tape := range tapes {
+			for j, cell := range tape.Cell {
 
-					if cell.S != nil && *cell.S == Prefix {
+				if i < startTape || (i == startTape && j < startCell) {
+					continue
+				}
+
+				// Tapes after the envelope start were split at a pushed pipe
+				if r.allPipes && j == 0 && len(data) > 1 && envelope != EnvelopeInscription &&
+					(cell.S == nil || *cell.S != Prefix) {
+					data = append(data, pipe)
+				}
+
+				// Separators already pushed as data (inscriptions) are not repeated
+				if cell.S != nil && *cell.S == Prefix {
+					if r.pipes && !(envelope == EnvelopeInscription && piped) {
 						data = append(data, pipe)
-						a.Data = data
-						if i == aipTapeIndex && j >= aipCellIndex {
-							return
-						}
 					}
+					if i == aipTapeIndex && j >= aipCellIndex {
+						return data, true
+					}
+				}
+
+				// Skip the OPS
+				// if cell.Ops != nil {
+				if cell.Op != nil && (*cell.Op == 0 || *cell.Op > 0x4e) {
+					continue
+				}
+				if cell.S != nil {
 
-					// Skip the OPS
-					// if cell.Ops != nil {
-					if cell.Op != nil && (*cell.Op == 0 || *cell.Op > 0x4e) {
-						continue
+					// Inscriptions push their pipes, other envelopes never keep them in tapes
+					if envelope == EnvelopeInscription {
+						if *cell.S == pipe && !r.pipes {
+							continue
+						}
+						piped = *cell.S == pipe
 					}
-					if cell.S != nil {
+					if r.trim {
 						data = append(data, strings.TrimSpace(*cell.S))
+					} else {
+						data = append(data, *cell.S)
 					}
-
 				}
+
 			}
+		}
 
-		} else {
+	} else {
 
-			var indexCt = 0
+		// Indices count every cell of the output, each cell left out is a pipe
+		var indexCt = 0
+		skip := r.envelope || envelope == EnvelopeInscription
 
-			for _, tape := range tapes {
-				for _, cell := range tape.Cell {
-					if cell.S != nil && *cell.S != Prefix && contains(a.Indices, indexCt) {
-						data = append(data, *cell.S)
-					} else {
-						data = append(data, pipe)
-					}
+		for i, tape := range tapes {
+			for j, cell := range tape.Cell {
+				if skip && (i < startTape || (i == startTape && j < startCell)) {
 					indexCt++
+					continue
 				}
+				if cell.S != nil && *cell.S != Prefix && contains(a.Indices, indexCt) {
+					data = append(data, *cell.S)
+				} else if r.pipes {
+					data = append(data, pipe)
+				}
+				indexCt++
 			}
 		}
-		a.Data = data
 	}
-
+	return data, true
 }
 
 // SignBobOpReturnData appends a signature to a BOB Tx by adding a
@@ -200,6 +254,7 @@ func SignBobOpReturnData(privateKey *ec.PrivateKey, algorithm Algorithm, output
 
 	hexAlgoSigningComponent := hex.EncodeToString([]byte(a.AlgorithmSigningComponent))
 	hexSig := hex.EncodeToString([]byte(a.Signature))
+	a.SignatureEncoding = SignatureBase64
 
 	// Create the output tape
 	output.Tape = append(output.Tape, bpu.Tape{
@@ -251,6 +306,11 @@ func contains(s []int, e int) bool {
 
 // NewFromAllTapes will create all AIP objects from a []bob.Tape
 func NewFromAllTapes(tapes []bpu.Tape) []*Aip {
+	return allFromTapes(tapes, defaultReconstruction)
+}
+
+// allFromTapes returns every AIP in the tapes, rebuilding the data with the given reconstruction
+func allFromTapes(tapes []bpu.Tape, r reconstruction) []*Aip {
 	var aips []*Aip
 
 	// Find all tapes that contain the AIP prefix
@@ -261,7 +321,9 @@ func NewFromAllTapes(tapes []bpu.Tape) []*Aip {
 				a := new(Aip)
 				a.FromTape(t)
 				// For all AIP entries, include all data from the start up to this entry
-				a.SetDataFromTapes(tapes[:i+1], instance)
+				if data, found := a.dataFromTapes(tapes[:i+1], instance, r); found {
+					a.Data = data
+				}
 				instance++
 				aips = append(aips, a)
 				continue
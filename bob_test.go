@@ -3,6 +3,7 @@ package aip
 import (
 	"encoding/hex"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/bitcoinschema/go-bob"
@@ -230,6 +231,85 @@ func BenchmarkNewFromTapes(b *testing.B) {
 	}
 }
 
+// TestDataFromTapes_Envelope will test dataFromTapes() pipe handling and index offsets by envelope
+func TestDataFromTapes_Envelope(t *testing.T) {
+	t.Parallel()
+
+	str := func(s string) bpu.Cell { return bpu.Cell{S: &s} }
+	op := func(o uint8) bpu.Cell { return bpu.Cell{Op: &o} }
+	aipTape := bpu.Tape{Cell: []bpu.Cell{str(Prefix), str(string(BitcoinECDSA)), str("addr"), str("sig")}}
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name         string
+			tapes        []bpu.Tape
+			indices      []int
+			r            reconstruction
+			expectedData []string
+		}{
+			{
+				"pushed pipe in op_return is data",
+				[]bpu.Tape{{Cell: []bpu.Cell{op(script.OpRETURN)}}, {Cell: []bpu.Cell{str("a"), str(pipe)}}, aipTape},
+				nil,
+				reconstruction{pipes: true},
+				[]string{opReturn, "a", pipe, pipe},
+			},
+			{
+				"pushed pipe in op_return without pipes",
+				[]bpu.Tape{{Cell: []bpu.Cell{op(script.OpRETURN)}}, {Cell: []bpu.Cell{str("a"), str(pipe)}}, aipTape},
+				nil,
+				reconstruction{},
+				[]string{opReturn, "a", pipe},
+			},
+			{
+				"inscription pipe is not repeated",
+				[]bpu.Tape{{Cell: []bpu.Cell{op(script.OpFALSE), op(script.OpIF), str("a"), str(pipe), aipTape.Cell[0], aipTape.Cell[1], aipTape.Cell[2], aipTape.Cell[3]}}},
+				nil,
+				reconstruction{pipes: true},
+				[]string{opReturn, "a", pipe},
+			},
+			{
+				"indices keep a pipe for each locking script cell",
+				[]bpu.Tape{{Cell: []bpu.Cell{op(script.Op1), op(script.OpRETURN)}}, {Cell: []bpu.Cell{str("a"), str("b")}}, {Cell: aipTape.Cell[:1]}},
+				[]int{3},
+				reconstruction{pipes: true},
+				[]string{opReturn, pipe, pipe, pipe, "b", pipe},
+			},
+			{
+				"indices skip the locking script with the envelope interpretation",
+				[]bpu.Tape{{Cell: []bpu.Cell{op(script.Op1), op(script.OpRETURN)}}, {Cell: []bpu.Cell{str("a"), str("b")}}, {Cell: aipTape.Cell[:1]}},
+				[]int{3},
+				reconstruction{pipes: true, envelope: true},
+				[]string{opReturn, pipe, "b", pipe},
+			},
+			{
+				"indices skip the inscription locking script",
+				[]bpu.Tape{{Cell: []bpu.Cell{op(script.OpFALSE), op(script.OpIF), str("a"), str("b"), aipTape.Cell[0]}}},
+				[]int{3},
+				reconstruction{pipes: true},
+				[]string{opReturn, pipe, "b", pipe},
+			},
+			{
+				"indices without pipes",
+				[]bpu.Tape{{Cell: []bpu.Cell{op(script.Op1), op(script.OpRETURN)}}, {Cell: []bpu.Cell{str("a"), str("b")}}, {Cell: aipTape.Cell[:1]}},
+				[]int{2, 3},
+				reconstruction{envelope: true},
+				[]string{opReturn, "a", "b"},
+			},
+		}
+	)
+
+	for _, test := range tests {
+		a := &Aip{Indices: test.indices}
+		if data, found := a.dataFromTapes(test.tapes, 0, test.r); !found {
+			t.Errorf("%s Failed: [%s] expected the AIP to be found", t.Name(), test.name)
+		} else if !reflect.DeepEqual(data, test.expectedData) {
+			t.Errorf("%s Failed: [%s] expected data %q but got %q", t.Name(), test.name, test.expectedData, data)
+		}
+	}
+}
+
 // TestValidateTapes will test the method ValidateTapes()
 func TestValidateTapes(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,102 @@
+package aip
+
+import (
+	"bytes"
+	"errors"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Placeholder sizes used to estimate an output before it is signed
+const (
+	addressSize     = 34 // Longest mainnet P2PKH address
+	identityKeySize = 66 // Compressed public key (hex)
+	signatureSize   = 65 // Compact signature (raw)
+)
+
+// NewSignedOutput signs the protocol segments and returns an OP_FALSE OP_RETURN output
+//
+// The output is: OP_FALSE OP_RETURN <segment> | <segment> | ... | <AIP>. The
+// signature covers every segment push followed by a pipe, which is the payload
+// SetDataFromTapes rebuilds from BOB tapes (where separators are not cells).
+func NewSignedOutput(privateKey *ec.PrivateKey, algorithm Algorithm,
+	segments ...[][]byte) (*transaction.TransactionOutput, *Aip, error) {
+
+	pushes, signed, err := segmentPushes(segments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var a *Aip
+	if a, err = Sign(privateKey, algorithm, string(bytes.Join(signed, []byte{}))+pipe); err != nil {
+		return nil, nil, err
+	}
+	var aipPushes [][]byte
+	if aipPushes, err = a.MarshalPushes(); err != nil {
+		return nil, nil, err
+	}
+	s, err := NewEnvelopeScript(EnvelopeOpFalseOpReturn, nil, append(pushes, aipPushes...))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &transaction.TransactionOutput{LockingScript: s}, a, nil
+}
+
+// AddSignedOutput signs the protocol segments and adds the output to the transaction
+//
+// Add funding inputs and change outputs as usual, then call tx.Fee() and tx.Sign()
+func AddSignedOutput(tx *transaction.Transaction, privateKey *ec.PrivateKey, algorithm Algorithm,
+	segments ...[][]byte) (*Aip, error) {
+
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+	output, a, err := NewSignedOutput(privateKey, algorithm, segments...)
+	if err != nil {
+		return nil, err
+	}
+	tx.AddOutput(output)
+	return a, nil
+}
+
+// SignedOutputSize returns the serialized size (bytes) of the signed output, before signing
+//
+// The size is exact for the paymail algorithm and an upper bound (by one byte)
+// for address algorithms, so it can be used for fee estimation and coin selection.
+func SignedOutputSize(algorithm Algorithm, segments ...[][]byte) (int, error) {
+	pushes, _, err := segmentPushes(segments)
+	if err != nil {
+		return 0, err
+	}
+	componentSize := addressSize
+	if algorithm == Paymail {
+		componentSize = identityKeySize
+	}
+	s, err := NewEnvelopeScript(EnvelopeOpFalseOpReturn, nil, append(pushes,
+		[]byte(Prefix),
+		[]byte(algorithm),
+		make([]byte, componentSize),
+		make([]byte, signatureSize),
+	))
+	if err != nil {
+		return 0, err
+	}
+	return 8 + transaction.VarInt(len(*s)).Length() + len(*s), nil
+}
+
+// segmentPushes returns the pushes (segments separated by pipes) and the signed data pushes
+func segmentPushes(segments [][][]byte) ([][]byte, [][]byte, error) {
+	if len(segments) == 0 {
+		return nil, nil, errors.New("at least one protocol segment is required")
+	}
+	var pushes, signed [][]byte
+	for _, segment := range segments {
+		if len(segment) == 0 {
+			return nil, nil, errors.New("protocol segment is empty")
+		}
+		pushes = append(append(pushes, segment...), []byte(pipe))
+		signed = append(signed, segment...)
+	}
+	return pushes, signed, nil
+}
@@ -0,0 +1,144 @@
+package aip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	feemodel "github.com/bsv-blockchain/go-sdk/transaction/fee_model"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+)
+
+// Example protocol segments (B and MAP)
+var (
+	exampleBSegment   = [][]byte{[]byte("19HxigV4QyBv3tHpQVcUEQyq1pzZVdoAut"), []byte("hello world"), []byte("text/plain"), []byte("utf8")}
+	exampleMapSegment = [][]byte{[]byte(MapPrefix), []byte("SET"), []byte("app"), []byte("go-aip")}
+)
+
+// fundedTx returns a transaction spending a mocked P2PKH UTXO of the example key
+func fundedTx(t testing.TB, satoshis uint64) (*transaction.Transaction, *script.Script) {
+	tx, lock, err := newFundedTx(satoshis)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return tx, lock
+}
+
+// newFundedTx is fundedTx returning the error (for examples)
+func newFundedTx(satoshis uint64) (*transaction.Transaction, *script.Script, error) {
+	addr, _ := script.NewAddressFromPublicKey(examplePrivateKey.PubKey(), true)
+	lock, _ := p2pkh.Lock(addr)
+	unlock, err := p2pkh.Unlock(examplePrivateKey, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	txID, _ := chainhash.NewHashFromHex(exampleSourceTxID)
+
+	tx := transaction.NewTransaction()
+	if err = tx.AddInputsFromUTXOs(&transaction.UTXO{
+		TxID:                    txID,
+		Vout:                    0,
+		LockingScript:           lock,
+		Satoshis:                satoshis,
+		UnlockingScriptTemplate: unlock,
+	}); err != nil {
+		return nil, nil, err
+	}
+	return tx, lock, nil
+}
+
+// TestAddSignedOutput will test the method AddSignedOutput()
+func TestAddSignedOutput(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name           string
+			inputAlgorithm Algorithm
+			inputSegments  [][][]byte
+			expectedError  bool
+		}{
+			{"single segment", BitcoinECDSA, [][][]byte{exampleBSegment}, false},
+			{"two segments", BitcoinSignedMessage, [][][]byte{exampleBSegment, exampleMapSegment}, false},
+			{"paymail", Paymail, [][][]byte{exampleBSegment, exampleMapSegment}, false},
+			{"no segments", BitcoinECDSA, nil, true},
+			{"empty segment", BitcoinECDSA, [][][]byte{exampleBSegment, {}}, true},
+		}
+	)
+
+	for _, test := range tests {
+		tx, lock := fundedTx(t, 1000)
+		a, err := AddSignedOutput(tx, examplePrivateKey, test.inputAlgorithm, test.inputSegments...)
+		if err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+			continue
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] error was expected", t.Name(), test.name)
+			continue
+		} else if err != nil {
+			continue
+		}
+
+		// Fund, add change and sign offline
+		tx.AddOutput(&transaction.TransactionOutput{LockingScript: lock, Change: true})
+		if err = tx.Fee(&feemodel.SatoshisPerKilobyte{Satoshis: 50}, transaction.ChangeDistributionEqual); err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if err = tx.Sign(); err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		}
+
+		// The estimated size is at most one byte larger than the real output
+		var size int
+		if size, err = SignedOutputSize(test.inputAlgorithm, test.inputSegments...); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if actual := len(tx.Outputs[0].Bytes()); size < actual || size > actual+1 {
+			t.Errorf("%s Failed: [%s] expected size [%d] to cover [%d]", t.Name(), test.name, size, actual)
+		}
+
+		// The signed output validates from the serialized transaction
+		var result *ValidationResult
+		if result, err = ValidateTapesWithOptions(parseScript(t, tx.Outputs[0].LockingScript), nil); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if !result.Valid || result.Aip.AlgorithmSigningComponent != a.AlgorithmSigningComponent {
+			t.Errorf("%s Failed: [%s] expected a valid signature but got [%t]", t.Name(), test.name, result.Valid)
+		}
+		if fee, _ := tx.GetFee(); fee != 50 || tx.Outputs[1].Satoshis != 950 {
+			t.Errorf("%s Failed: [%s] expected fee [50] and change [950] but got [%d] [%d]", t.Name(), test.name, fee, tx.Outputs[1].Satoshis)
+		}
+	}
+
+	if _, err := AddSignedOutput(nil, examplePrivateKey, BitcoinECDSA, exampleBSegment); err == nil {
+		t.Errorf("%s Failed: error was expected (missing transaction)", t.Name())
+	}
+}
+
+// ExampleAddSignedOutput example using AddSignedOutput()
+func ExampleAddSignedOutput() {
+	tx, lock, err := newFundedTx(1000)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	if _, err = AddSignedOutput(tx, examplePrivateKey, BitcoinECDSA, exampleBSegment, exampleMapSegment); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	tx.AddOutput(&transaction.TransactionOutput{LockingScript: lock, Change: true})
+	if err = tx.Fee(&feemodel.SatoshisPerKilobyte{Satoshis: 50}, transaction.ChangeDistributionEqual); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	size, _ := SignedOutputSize(BitcoinECDSA, exampleBSegment, exampleMapSegment)
+	fmt.Printf("outputs: %d change: %d estimated size: %d", len(tx.Outputs), tx.Outputs[1].Satoshis, size)
+	// Output:outputs: 2 change: 950 estimated size: 280
+}
+
+// BenchmarkNewSignedOutput benchmarks the method NewSignedOutput()
+func BenchmarkNewSignedOutput(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _ = NewSignedOutput(examplePrivateKey, BitcoinECDSA, exampleBSegment, exampleMapSegment)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/bitcoinschema/go-aip"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// privateKeyEnv holds the signing key (hex or WIF) when no key file is given
+const privateKeyEnv = "AIP_PRIVATE_KEY"
+
+// runSign writes a detached signature (sidecar) for a file
+func runSign(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("sign", flag.ContinueOnError)
+	keyFile := fs.String("key-file", "", "file holding the private key, hex or WIF (default: $"+privateKeyEnv+")")
+	algorithm := fs.String("algorithm", string(aip.BitcoinSignedMessage), "signing algorithm: BITCOIN_ECDSA, BitcoinSignedMessage or paymail")
+	armor := fs.Bool("armor", false, "write armored text (default: JSON)")
+	output := fs.String("o", "", "write the sidecar to a file (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	privateKey, err := loadPrivateKey(*keyFile)
+	if err != nil {
+		return err
+	}
+	var in io.ReadCloser
+	if in, err = openInput(fs, stdin); err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	var d *aip.DetachedSignature
+	if d, err = aip.SignDetached(privateKey, aip.Algorithm(*algorithm), in); err != nil {
+		return err
+	}
+	sidecar := d.MarshalArmor()
+	if !*armor {
+		if sidecar, err = json.MarshalIndent(d, "", "  "); err != nil {
+			return err
+		}
+		sidecar = append(sidecar, '\n')
+	}
+	if len(*output) > 0 {
+		return os.WriteFile(*output, sidecar, 0o600)
+	}
+	_, err = stdout.Write(sidecar)
+	return err
+}
+
+// runVerify verifies a file against its detached signature
+func runVerify(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	sigFile := fs.String("sig", "", "the sidecar file, JSON or armored (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	} else if len(*sigFile) == 0 {
+		return errors.New("-sig is required")
+	}
+
+	raw, err := os.ReadFile(*sigFile)
+	if err != nil {
+		return err
+	}
+	var d *aip.DetachedSignature
+	if d, err = aip.ParseDetachedSignature(raw); err != nil {
+		return err
+	}
+	var in io.ReadCloser
+	if in, err = openInput(fs, stdin); err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	var result *aip.ValidationResult
+	if result, err = aip.VerifyDetached(in, d); err != nil {
+		return err
+	}
+	log.Printf("valid signature for %s", d.Digest)
+	_, err = fmt.Fprintln(stdout, result.Signer)
+	return err
+}
+
+// loadPrivateKey reads a hex or WIF private key from the file or the environment
+func loadPrivateKey(keyFile string) (*ec.PrivateKey, error) {
+	key := os.Getenv(privateKeyEnv)
+	if len(keyFile) > 0 {
+		raw, err := os.ReadFile(keyFile) //nolint:gosec // The path is chosen by the user
+		if err != nil {
+			return nil, err
+		}
+		key = string(raw)
+	}
+	key = strings.TrimSpace(key)
+	if len(key) == 0 {
+		return nil, fmt.Errorf("a private key is required (-key-file or $%s)", privateKeyEnv)
+	}
+	if privateKey, err := ec.PrivateKeyFromHex(key); err == nil {
+		return privateKey, nil
+	}
+	return ec.PrivateKeyFromWif(key)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Key and address used by the tests
+const (
+	examplePrivateKeyHex = "54035dd4c7dda99ac473905a3d82f7864322b49bab1ff441cc457183b9bd8abd"
+	exampleAddress       = "1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK"
+)
+
+// writeTestFile writes the content to a file in the test's temporary directory
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return path
+}
+
+// TestRunSign will test the sign command
+func TestRunSign(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	keyFile := writeTestFile(t, dir, "key", examplePrivateKeyHex+"\n")
+
+	var out bytes.Buffer
+	if err := runSign([]string{"-key-file", keyFile}, strings.NewReader("hello world"), &out); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if !strings.Contains(out.String(), exampleAddress) || !strings.HasPrefix(out.String(), "{") {
+		t.Errorf("%s Failed: expected a JSON sidecar but got [%s]", t.Name(), out.String())
+	}
+
+	out.Reset()
+	if err := runSign([]string{"-key-file", keyFile, "-armor"}, strings.NewReader("hello world"), &out); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if strings.HasPrefix(out.String(), "{") {
+		t.Errorf("%s Failed: expected an armored sidecar but got [%s]", t.Name(), out.String())
+	}
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name string
+			args []string
+		}{
+			{"missing key file", []string{"-key-file", filepath.Join(dir, "missing")}},
+			{"invalid key", []string{"-key-file", writeTestFile(t, dir, "bad", "not a key")}},
+			{"unknown algorithm", []string{"-key-file", keyFile, "-algorithm", "RSA"}},
+			{"missing input", []string{"-key-file", keyFile, filepath.Join(dir, "missing")}},
+		}
+	)
+
+	for _, test := range tests {
+		if err := runSign(test.args, strings.NewReader("hello world"), &bytes.Buffer{}); err == nil {
+			t.Errorf("%s Failed: [%s] error was expected", t.Name(), test.name)
+		}
+	}
+}
+
+// TestRunVerify will test the verify command with sidecars written by the sign command
+func TestRunVerify(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	keyFile := writeTestFile(t, dir, "key", examplePrivateKeyHex)
+	file := writeTestFile(t, dir, "file.txt", "hello world")
+
+	for _, armor := range []bool{false, true} {
+		sidecar := filepath.Join(dir, "file.sig")
+		args := []string{"-key-file", keyFile, "-o", sidecar, file}
+		if armor {
+			args = append([]string{"-armor"}, args...)
+		}
+		if err := runSign(args, nil, &bytes.Buffer{}); err != nil {
+			t.Fatalf("%s Failed: [armor %t] error not expected but got: %s", t.Name(), armor, err.Error())
+		}
+
+		var out bytes.Buffer
+		if err := runVerify([]string{"-sig", sidecar, file}, nil, &out); err != nil {
+			t.Errorf("%s Failed: [armor %t] error not expected but got: %s", t.Name(), armor, err.Error())
+		} else if strings.TrimSpace(out.String()) != exampleAddress {
+			t.Errorf("%s Failed: [armor %t] expected [%s] but got [%s]", t.Name(), armor, exampleAddress, out.String())
+		}
+
+		// Changed content, read from stdin
+		if err := runVerify([]string{"-sig", sidecar}, strings.NewReader("hello world!"), &bytes.Buffer{}); err == nil {
+			t.Errorf("%s Failed: [armor %t] error was expected (changed content)", t.Name(), armor)
+		}
+	}
+
+	if err := runVerify(nil, strings.NewReader("hello world"), &bytes.Buffer{}); err == nil {
+		t.Errorf("%s Failed: error was expected (missing -sig)", t.Name())
+	}
+	if err := runVerify([]string{"-sig", writeTestFile(t, dir, "bad.sig", "not a sidecar")}, strings.NewReader("hello world"), &bytes.Buffer{}); err == nil {
+		t.Errorf("%s Failed: error was expected (invalid sidecar)", t.Name())
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bitcoinschema/go-aip"
+	"github.com/bitcoinschema/go-bpu"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// explained is an explanation and the output it was found in (NDJSON output)
+type explained struct {
+	Vout int `json:"vout"`
+	*aip.Explanation
+}
+
+// runExplain prints a step by step validation report for the AIPs in a raw tx
+func runExplain(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("explain", flag.ContinueOnError)
+	vout := fs.Int("vout", -1, "only explain this output (default: every output)")
+	instance := fs.Int("instance", -1, "only explain this AIP in the output (default: every AIP)")
+	mode := fs.String("mode", string(aip.ModeStrict), "validation mode: strict or legacy")
+	signer := fs.String("signer", "", "expected signer: address, hex public key or hex hash160")
+	asJSON := fs.Bool("json", false, "write one JSON report per line")
+	if err := fs.Parse(args); err != nil {
+		return err
+	} else if m := aip.Mode(*mode); m != aip.ModeStrict && m != aip.ModeLegacy {
+		return fmt.Errorf("unknown validation mode: %s", m)
+	}
+
+	in, err := openInput(fs, stdin)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+	var raw []byte
+	if raw, err = io.ReadAll(in); err != nil {
+		return err
+	}
+	var tx *transaction.Transaction
+	if tx, err = transaction.NewTransactionFromHex(strings.TrimSpace(string(raw))); err != nil {
+		return err
+	}
+
+	found := false
+	enc := json.NewEncoder(stdout)
+	for i, out := range tx.Outputs {
+		if *vout >= 0 && i != *vout {
+			continue
+		}
+		var tapes []bpu.Tape
+		if tapes, err = aip.TapesFromScript(out.LockingScript); err != nil {
+			return fmt.Errorf("output %d: %w", i, err)
+		}
+		for n := range aip.NewFromAllTapes(tapes) {
+			if *instance >= 0 && n != *instance {
+				continue
+			}
+			found = true
+			e := aip.Explain(tapes, &aip.ValidateOptions{Mode: aip.Mode(*mode), Instance: n, Signer: *signer})
+			if *asJSON {
+				err = enc.Encode(explained{Vout: i, Explanation: e})
+			} else {
+				_, err = fmt.Fprintf(stdout, "output %d, %s\n", i, e)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		return errors.New("no AIP found")
+	}
+	return nil
+}
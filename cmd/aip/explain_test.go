@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestRunExplain will test the explain command
+func TestRunExplain(t *testing.T) {
+	t.Parallel()
+
+	rawTx := strings.SplitN(string(loadTestFile(t, "txs.hex")), "\n", 2)[0]
+
+	var out bytes.Buffer
+	if err := runExplain(nil, strings.NewReader(rawTx), &out); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if !strings.HasPrefix(out.String(), "output 0, AIP instance 0 (strict mode)") ||
+		!strings.Contains(out.String(), "result: valid (spec), signer 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK") {
+		t.Errorf("%s Failed: unexpected report [%s]", t.Name(), out.String())
+	}
+
+	// NDJSON, legacy mode
+	out.Reset()
+	if err := runExplain([]string{"-json", "-mode", "legacy", "-vout", "0"}, strings.NewReader(rawTx), &out); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	var lines int
+	for scanner := bufio.NewScanner(&out); scanner.Scan(); lines++ {
+		var e struct {
+			Vout int    `json:"vout"`
+			Mode string `json:"mode"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+		} else if e.Vout != 0 || e.Mode != "legacy" {
+			t.Errorf("%s Failed: unexpected report [%s]", t.Name(), scanner.Text())
+		}
+	}
+	if lines != 1 {
+		t.Errorf("%s Failed: expected [1] report but got [%d]", t.Name(), lines)
+	}
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name  string
+			args  []string
+			input string
+		}{
+			{"unknown mode", []string{"-mode", "lenient"}, rawTx},
+			{"no AIP in output", []string{"-vout", "5"}, rawTx},
+			{"no AIP instance", []string{"-instance", "3"}, rawTx},
+			{"invalid hex", nil, "not hex"},
+		}
+	)
+
+	for _, test := range tests {
+		if err := runExplain(test.args, strings.NewReader(test.input), &bytes.Buffer{}); err == nil {
+			t.Errorf("%s Failed: [%s] error was expected", t.Name(), test.name)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Command aip works with Author Identity Protocol (AIP) signatures
+//
+// Usage:
+//
+//	aip scan [-block] [-workers n] [-mode strict|legacy] [-diagnose] [-report path] [file]
+//	aip explain [-vout n] [-instance n] [-mode strict|legacy] [-signer id] [-json] [file]
+//	aip sign [-key-file path] [-algorithm name] [-armor] [-o sidecar] [file]
+//	aip verify -sig sidecar [file]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+)
+
+// command is a subcommand of the aip tool
+type command struct {
+	usage string
+	run   func(args []string, stdin io.Reader, stdout io.Writer) error
+}
+
+// commands are the available subcommands
+var commands = map[string]command{
+	"explain": {"report every validation step of the AIPs in a raw tx hex and which step failed", runExplain},
+	"scan":    {"validate every AIP in a raw block or newline-delimited raw tx hex (NDJSON output)", runScan},
+	"sign":    {"write a detached signature (JSON or armored sidecar) for a file", runSign},
+	"verify":  {"verify a file against its detached signature and print the signer", runVerify},
+}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+		log.Fatalf("error occurred: %s", err.Error())
+	}
+}
+
+// usage writes the available subcommands
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	_, _ = fmt.Fprintln(w, "usage: aip <command> [arguments]")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
+
+// openInput returns the named file, or stdin if no file (or "-") is given
+func openInput(fs *flag.FlagSet, stdin io.Reader) (io.ReadCloser, error) {
+	if fs.NArg() == 0 || fs.Arg(0) == "-" {
+		return io.NopCloser(stdin), nil
+	}
+	return os.Open(fs.Arg(0))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/bitcoinschema/go-aip"
+)
+
+// runScan validates every AIP in a raw block or a file of raw tx hex lines
+func runScan(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	block := fs.Bool("block", false, "input is a raw serialized block (default: one raw tx hex per line)")
+	workers := fs.Int("workers", 0, "number of parallel validators (default: number of CPUs)")
+	mode := fs.String("mode", string(aip.ModeStrict), "validation mode: strict or legacy")
+	diagnose := fs.Bool("diagnose", false, "search alternative payload reconstructions for failed signatures")
+	report := fs.String("report", "", "write a JSON data-quality report to this path (implies -diagnose)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	in, err := openInput(fs, stdin)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	opts := &aip.StreamOptions{Mode: aip.Mode(*mode), Workers: *workers, Diagnose: *diagnose || *report != ""}
+	if *report != "" {
+		opts.Report = new(aip.QualityReport)
+	}
+	var count int
+	if *block {
+		count, err = aip.ValidateBlock(in, stdout, opts)
+	} else {
+		count, err = aip.ValidateRawTxs(in, stdout, opts)
+	}
+	log.Printf("scanned %d transactions", count)
+	if err != nil || opts.Report == nil {
+		return err
+	}
+	var b []byte
+	if b, err = json.MarshalIndent(opts.Report, "", "  "); err != nil {
+		return err
+	}
+	return os.WriteFile(*report, append(b, '\n'), 0o600)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-aip"
+)
+
+// loadTestFile reads a file from the repository testdata
+func loadTestFile(t testing.TB, name string) []byte {
+	b, err := os.ReadFile(filepath.Join("..", "..", "testdata", name)) //nolint:gosec // Test fixture
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return b
+}
+
+// TestRunScan will test the scan command
+func TestRunScan(t *testing.T) {
+	t.Parallel()
+
+	expected := string(loadTestFile(t, "block.ndjson"))
+
+	// Raw tx hex lines from stdin
+	var out bytes.Buffer
+	if err := runScan([]string{"-workers", "2"}, bytes.NewReader(loadTestFile(t, "txs.hex")), &out); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if out.String() != expected {
+		t.Errorf("%s Failed: expected [%s] but got [%s]", t.Name(), expected, out.String())
+	}
+
+	// Raw block from a file
+	out.Reset()
+	if err := runScan([]string{"-block", filepath.Join("..", "..", "testdata", "block.bin")}, nil, &out); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if out.String() != expected {
+		t.Errorf("%s Failed: expected [%s] but got [%s]", t.Name(), expected, out.String())
+	}
+
+	// Data-quality report
+	report := filepath.Join(t.TempDir(), "report.json")
+	if err := runScan([]string{"-report", report}, bytes.NewReader(loadTestFile(t, "txs.hex")), &bytes.Buffer{}); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	var q aip.QualityReport
+	if b, err := os.ReadFile(report); err != nil { //nolint:gosec // Test file
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if err = json.Unmarshal(b, &q); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if q.Signatures != strings.Count(expected, "\n") || q.Valid+q.Invalid != q.Signatures {
+		t.Errorf("%s Failed: unexpected report [%+v]", t.Name(), q)
+	}
+
+	// An unknown mode is rejected before anything is written
+	out.Reset()
+	if err := runScan([]string{"-mode", "lenient"}, bytes.NewReader(loadTestFile(t, "txs.hex")), &out); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown mode)", t.Name())
+	} else if out.Len() != 0 {
+		t.Errorf("%s Failed: expected no output but got [%s]", t.Name(), out.String())
+	}
+	if err := runScan([]string{"-unknown"}, nil, &bytes.Buffer{}); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown flag)", t.Name())
+	}
+}
@@ -0,0 +1,215 @@
+package aip
+
+import (
+	"slices"
+
+	"github.com/bitcoinschema/go-bpu"
+)
+
+// CellRef is the position of a cell in an output's tapes
+type CellRef struct {
+	Tape int `json:"tape"` // Tape index
+	Cell int `json:"cell"` // Cell index in the tape
+}
+
+// Coverage is what a single AIP signature covers in an output
+type Coverage struct {
+	Aip          *Aip      `json:"aip"`                    // The parsed AIP
+	Cells        []CellRef `json:"cells"`                  // Every cell included in the signed payload
+	Countersigns []int     `json:"countersigns,omitempty"` // Instances of earlier AIPs whose signature is covered
+	Error        string    `json:"error,omitempty"`        // Why the signature is invalid
+	Instance     int       `json:"instance"`               // The AIP instance (0 = first)
+	Position     CellRef   `json:"position"`               // Position of the AIP prefix
+	Protocols    []string  `json:"protocols,omitempty"`    // Bitcom prefixes of the covered tapes
+	Signer       string    `json:"signer,omitempty"`       // The address recovered from the signature
+	Valid        bool      `json:"valid"`                  // True if the signature is valid
+}
+
+// NewCoverageMap reports, for every AIP in the tapes, exactly which cells it signs
+//
+// A later AIP signs every earlier AIP in its payload, so it countersigns them:
+// for "user signed content, app countersigned user" the app's coverage lists the
+// user's instance in Countersigns. The signed data is rebuilt from the exact
+// cells (the spec interpretation), whitespace included.
+func NewCoverageMap(tapes []bpu.Tape) []*Coverage {
+	positions := aipPositions(tapes)
+	coverages := make([]*Coverage, 0, len(positions))
+	for instance, position := range positions {
+		a := new(Aip)
+		a.FromTape(tapes[position.Tape])
+		if data, found := a.dataFromTapes(tapes[:position.Tape+1], instance, specReconstruction); found {
+			a.Data = data
+		}
+
+		c := &Coverage{
+			Aip:      a,
+			Cells:    a.coveredCells(tapes[:position.Tape+1], instance),
+			Instance: instance,
+			Position: position,
+		}
+		c.Protocols = protocols(tapes, c.Cells)
+		var err error
+		if c.Signer, err = a.signer(); err != nil {
+			c.Error = err.Error()
+		} else {
+			c.Valid = true
+		}
+
+		// An earlier AIP is countersigned when its signature cell is covered
+		covered := make(map[CellRef]bool, len(c.Cells))
+		for _, ref := range c.Cells {
+			covered[ref] = true
+		}
+		for other, p := range positions[:instance] {
+			if covered[CellRef{Tape: p.Tape, Cell: p.Cell + 3}] {
+				c.Countersigns = append(c.Countersigns, other)
+			}
+		}
+		coverages = append(coverages, c)
+	}
+	return coverages
+}
+
+// aipPositions returns the position of every AIP prefix in the tapes
+func aipPositions(tapes []bpu.Tape) []CellRef {
+	var positions []CellRef
+	for i, tape := range tapes {
+		for j, cell := range tape.Cell {
+			if cell.S != nil && *cell.S == Prefix {
+				positions = append(positions, CellRef{Tape: i, Cell: j})
+			}
+		}
+	}
+	return positions
+}
+
+// protocols returns the protocol (first data cell) of every tape holding a covered cell
+func protocols(tapes []bpu.Tape, cells []CellRef) []string {
+	var found []string
+	for _, ref := range protocolCells(tapes, cells) {
+		if cell := tapes[ref.Tape].Cell[ref.Cell]; cell.S != nil && !slices.Contains(found, *cell.S) {
+			found = append(found, *cell.S)
+		}
+	}
+	return found
+}
+
+// protocolCells returns the first data cell of every tape holding a covered cell
+func protocolCells(tapes []bpu.Tape, cells []CellRef) []CellRef {
+	_, startTape, startCell := envelopeStart(tapes)
+	var found []CellRef
+	seen := make(map[int]bool)
+	for _, ref := range cells {
+		if seen[ref.Tape] {
+			continue
+		}
+		seen[ref.Tape] = true
+		for j, cell := range tapes[ref.Tape].Cell {
+			if ref.Tape == startTape && j < startCell {
+				continue
+			}
+			if cell.Op != nil && (*cell.Op == 0 || *cell.Op > 0x4e) {
+				continue
+			}
+			found = append(found, CellRef{Tape: ref.Tape, Cell: j})
+			break
+		}
+	}
+	return found
+}
+
+// coveredCells returns the cells used by dataFromTapes for the given AIP instance
+//
+// Separators inserted by the reconstruction are not cells and are not reported
+func (a *Aip) coveredCells(tapes []bpu.Tape, instance int) []CellRef {
+	positions := aipPositions(tapes)
+	if instance >= len(positions) {
+		return nil
+	}
+	position := positions[instance]
+
+	var cells []CellRef
+	if len(a.Indices) == 0 {
+		_, startTape, startCell := envelopeStart(tapes)
+		for i, tape := range tapes {
+			for j, cell := range tape.Cell {
+				if i < startTape || (i == startTape && j < startCell) {
+					continue
+				}
+				if i == position.Tape && j >= position.Cell {
+					return cells
+				}
+				if cell.Op != nil && (*cell.Op == 0 || *cell.Op > 0x4e) {
+					continue
+				}
+				if cell.S != nil {
+					cells = append(cells, CellRef{Tape: i, Cell: j})
+				}
+			}
+		}
+		return cells
+	}
+
+	envelope, startTape, startCell := envelopeStart(tapes)
+	indexCt := 0
+	for i, tape := range tapes {
+		for j, cell := range tape.Cell {
+			if envelope == EnvelopeInscription && (i < startTape || (i == startTape && j < startCell)) {
+				indexCt++
+				continue
+			}
+			if cell.S != nil && *cell.S != Prefix && contains(a.Indices, indexCt) {
+				cells = append(cells, CellRef{Tape: i, Cell: j})
+			}
+			indexCt++
+		}
+	}
+	return cells
+}
+
+// UnsignedCell is a pushdata cell that no valid signature covers
+type UnsignedCell struct {
+	CellRef
+	Value bpu.Cell `json:"value"` // The cell as parsed by BOB
+}
+
+// UnsignedCells lists every pushdata after the envelope start not covered by a valid AIP
+//
+// Fields of a valid AIP (prefix, algorithm, component, signature, indices) are
+// authenticated by the signature itself. Everything else that was not signed,
+// such as fields left out of the indices, data appended after the last AIP or
+// data only covered by an invalid signature, can be changed by anyone and is
+// returned so consumers can flag or strip it. Signed whitespace is part of the
+// signed cell, so a cell is never reported because it carries whitespace.
+func UnsignedCells(tapes []bpu.Tape) []UnsignedCell {
+	covered := make(map[CellRef]bool)
+	for _, c := range NewCoverageMap(tapes) {
+		if !c.Valid {
+			continue
+		}
+		for _, ref := range c.Cells {
+			covered[ref] = true
+		}
+		for j := c.Position.Cell; j < len(tapes[c.Position.Tape].Cell); j++ {
+			covered[CellRef{Tape: c.Position.Tape, Cell: j}] = true
+		}
+	}
+
+	var unsigned []UnsignedCell
+	_, startTape, startCell := envelopeStart(tapes)
+	for i, tape := range tapes {
+		for j, cell := range tape.Cell {
+			ref := CellRef{Tape: i, Cell: j}
+			if i < startTape || (i == startTape && j < startCell) || covered[ref] {
+				continue
+			}
+			if cell.Op != nil && (*cell.Op == 0 || *cell.Op > 0x4e) {
+				continue
+			}
+			if cell.S != nil || cell.B != nil {
+				unsigned = append(unsigned, UnsignedCell{CellRef: ref, Value: cell})
+			}
+		}
+	}
+	return unsigned
+}
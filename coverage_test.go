@@ -0,0 +1,239 @@
+package aip
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bitcoinschema/go-bpu"
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// TestNewCoverageMap will test the method NewCoverageMap()
+func TestNewCoverageMap(t *testing.T) {
+	t.Parallel()
+
+	tapes := parseScript(t, multiSignedScript(t, [][]byte{[]byte("hello world"), []byte("text/plain")},
+		multiSigner{examplePrivateKey, BitcoinECDSA}, multiSigner{secondPrivateKey, BitcoinECDSA}, multiSigner{thirdPrivateKey, Paymail},
+	))
+	coverages := NewCoverageMap(tapes)
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			expectedCells        string
+			expectedCountersigns string
+			expectedPosition     CellRef
+			expectedSigner       string
+		}{
+			{"[{1 0} {1 1}]", "[]", CellRef{Tape: 2, Cell: 0}, address(examplePrivateKey)},
+			{"[{1 0} {1 1} {2 0} {2 1} {2 2} {2 3}]", "[0]", CellRef{Tape: 3, Cell: 0}, address(secondPrivateKey)},
+			{"[{1 0} {1 1} {2 0} {2 1} {2 2} {2 3} {3 0} {3 1} {3 2} {3 3}]", "[0 1]", CellRef{Tape: 4, Cell: 0}, address(thirdPrivateKey)},
+		}
+	)
+
+	if len(coverages) != len(tests) {
+		t.Fatalf("%s Failed: expected [%d] coverages but got [%d]", t.Name(), len(tests), len(coverages))
+	}
+	for i, test := range tests {
+		c := coverages[i]
+		if c.Instance != i || c.Position != test.expectedPosition {
+			t.Errorf("%s Failed: [%d] expected [%v] but got [%d] [%v]", t.Name(), i, test.expectedPosition, c.Instance, c.Position)
+		} else if fmt.Sprint(c.Cells) != test.expectedCells {
+			t.Errorf("%s Failed: [%d] expected [%s] but got [%v]", t.Name(), i, test.expectedCells, c.Cells)
+		} else if fmt.Sprint(c.Countersigns) != test.expectedCountersigns {
+			t.Errorf("%s Failed: [%d] expected [%s] but got [%v]", t.Name(), i, test.expectedCountersigns, c.Countersigns)
+		} else if len(c.Protocols) != min(i+1, 2) || c.Protocols[0] != "hello world" {
+			t.Errorf("%s Failed: [%d] unexpected protocols %v", t.Name(), i, c.Protocols)
+		} else if !c.Valid || c.Signer != test.expectedSigner {
+			t.Errorf("%s Failed: [%d] expected [%s] but got [%t] [%s] [%s]", t.Name(), i, test.expectedSigner, c.Valid, c.Signer, c.Error)
+		}
+	}
+}
+
+// TestNewCoverageMap_Whitespace will test NewCoverageMap() keeps signed whitespace
+func TestNewCoverageMap_Whitespace(t *testing.T) {
+	t.Parallel()
+
+	tapes := parseScript(t, multiSignedScript(t, [][]byte{[]byte(" hello world\n"), []byte("text/plain")},
+		multiSigner{examplePrivateKey, BitcoinECDSA}, multiSigner{secondPrivateKey, BitcoinECDSA},
+	))
+	coverages := NewCoverageMap(tapes)
+	if len(coverages) != 2 {
+		t.Fatalf("%s Failed: expected [2] coverages but got [%d]", t.Name(), len(coverages))
+	}
+	for i, c := range coverages {
+		if !c.Valid {
+			t.Errorf("%s Failed: [%d] expected a valid signature but got [%s]", t.Name(), i, c.Error)
+		} else if c.Aip.Data[1] != " hello world\n" {
+			t.Errorf("%s Failed: [%d] expected the untrimmed content but got [%q]", t.Name(), i, c.Aip.Data[1])
+		}
+	}
+}
+
+// TestNewCoverageMap_Indices will test NewCoverageMap() with signed indices
+func TestNewCoverageMap_Indices(t *testing.T) {
+	t.Parallel()
+
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	tapes := bobValidData.Out[0].Tape
+	tapes[len(tapes)-1].Cell = append(tapes[len(tapes)-1].Cell, tapes[len(tapes)-1].Cell[1], tapes[len(tapes)-1].Cell[1])
+	index, cell := "1", "3"
+	tapes[len(tapes)-1].Cell[len(tapes[len(tapes)-1].Cell)-2].S = &index
+	tapes[len(tapes)-1].Cell[len(tapes[len(tapes)-1].Cell)-1].S = &cell
+
+	coverages := NewCoverageMap(tapes)
+	if len(coverages) != 1 {
+		t.Fatalf("%s Failed: expected [1] coverage but got [%d]", t.Name(), len(coverages))
+	}
+	for _, ref := range coverages[0].Cells {
+		if ref.Tape != 0 && ref.Tape != 1 {
+			t.Errorf("%s Failed: unexpected covered cell [%v]", t.Name(), ref)
+		}
+	}
+
+	// No AIPs
+	if coverages = NewCoverageMap(tapes[:1]); len(coverages) != 0 {
+		t.Errorf("%s Failed: expected no coverage but got [%d]", t.Name(), len(coverages))
+	}
+}
+
+// ExampleNewCoverageMap example using NewCoverageMap()
+func ExampleNewCoverageMap() {
+	s, err := newMultiSignedScript([][]byte{[]byte("hello world")},
+		multiSigner{examplePrivateKey, BitcoinECDSA}, multiSigner{secondPrivateKey, BitcoinECDSA},
+	)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	var tapes []bpu.Tape
+	if tapes, err = scriptTapes(s); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	for _, c := range NewCoverageMap(tapes) {
+		fmt.Printf("instance: %d signer: %s cells: %d countersigns: %v\n", c.Instance, c.Signer, len(c.Cells), c.Countersigns)
+	}
+	// Output:instance: 0 signer: 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK cells: 1 countersigns: []
+	// instance: 1 signer: 1FiyJnrgwBc3Ff83V1yRWAkmXBdGrDQnXQ cells: 5 countersigns: [0]
+}
+
+// BenchmarkNewCoverageMap benchmarks the method NewCoverageMap()
+func BenchmarkNewCoverageMap(b *testing.B) {
+	tapes := parseScript(b, multiSignedScript(b, [][]byte{[]byte("hello world")},
+		multiSigner{examplePrivateKey, BitcoinECDSA}, multiSigner{secondPrivateKey, BitcoinECDSA},
+	))
+	for i := 0; i < b.N; i++ {
+		_ = NewCoverageMap(tapes)
+	}
+}
+
+// indexedScript builds OP_FALSE OP_RETURN <parts> | <AIP> where the AIP only signs the given indices
+func indexedScript(t testing.TB, parts [][]byte, indices ...string) *script.Script {
+	build := func(sig []byte, component string) *script.Script {
+		s := &script.Script{}
+		_ = s.AppendOpcodes(script.OpFALSE, script.OpRETURN)
+		pushes := append(append([][]byte{}, parts...), []byte(pipe), []byte(Prefix), []byte(BitcoinECDSA), []byte(component), sig)
+		for _, index := range indices {
+			pushes = append(pushes, []byte(index))
+		}
+		_ = s.AppendPushDataArray(pushes)
+		return s
+	}
+	aips := allFromTapes(parseScript(t, build([]byte("-"), "-")), specReconstruction)
+	a, err := Sign(examplePrivateKey, BitcoinECDSA, strings.Join(aips[0].Data[1:], ""))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var pushes [][]byte
+	if pushes, err = a.MarshalPushes(); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return build(pushes[3], a.AlgorithmSigningComponent)
+}
+
+// TestUnsignedCells will test the method UnsignedCells()
+func TestUnsignedCells(t *testing.T) {
+	t.Parallel()
+
+	content := [][]byte{[]byte("hello world"), []byte("text/plain"), []byte("utf8")}
+	signed := multiSignedScript(t, content, multiSigner{examplePrivateKey, BitcoinECDSA}, multiSigner{secondPrivateKey, BitcoinECDSA})
+
+	// Data appended after the last signature
+	appended := &script.Script{}
+	*appended = append(*appended, *signed...)
+	_ = appended.AppendPushDataArray([][]byte{[]byte(pipe), []byte("1PuQa7K62MiKCtssSLKy1kh56WWU7MtUR5"), []byte("SET")})
+
+	// Content changed after signing (the signature becomes invalid)
+	tampered := multiSignedScript(t, content, multiSigner{examplePrivateKey, BitcoinECDSA})
+	chunks, _ := tampered.Chunks()
+	*tampered = script.Script{}
+	for _, chunk := range chunks {
+		if string(chunk.Data) == "utf8" {
+			chunk.Data = []byte("binary")
+		}
+		if chunk.Data == nil {
+			_ = tampered.AppendOpcodes(chunk.Op)
+		} else {
+			_ = tampered.AppendPushData(chunk.Data)
+		}
+	}
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name          string
+			inputScript   *script.Script
+			expectedCells string
+		}{
+			{"fully signed", signed, "[]"},
+			{"appended after signing", appended, "[{4 0} {4 1}]"},
+			{"tampered content", tampered, "[{1 0} {1 1} {1 2} {2 0} {2 1} {2 2} {2 3}]"},
+			{"signed indices", indexedScript(t, content, "2", "4"), "[{1 1}]"},
+			{"signed whitespace", multiSignedScript(t, [][]byte{[]byte(" hello world\n"), []byte("text/plain ")}, multiSigner{examplePrivateKey, BitcoinECDSA}), "[]"},
+			{"signed whitespace indices", indexedScript(t, [][]byte{[]byte(" hello world\n"), []byte("text/plain ")}, "3"), "[{1 0}]"},
+		}
+	)
+
+	for _, test := range tests {
+		var refs []CellRef
+		for _, cell := range UnsignedCells(parseScript(t, test.inputScript)) {
+			refs = append(refs, cell.CellRef)
+		}
+		if fmt.Sprint(refs) != test.expectedCells {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%v]", t.Name(), test.name, test.expectedCells, refs)
+		}
+	}
+}
+
+// ExampleUnsignedCells example using UnsignedCells()
+func ExampleUnsignedCells() {
+	s, err := newMultiSignedScript([][]byte{[]byte("hello world")}, multiSigner{examplePrivateKey, BitcoinECDSA})
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	_ = s.AppendPushDataArray([][]byte{[]byte(pipe), []byte("added later")})
+	var tapes []bpu.Tape
+	if tapes, err = scriptTapes(s); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	for _, cell := range UnsignedCells(tapes) {
+		fmt.Printf("tape: %d cell: %d value: %s", cell.Tape, cell.Cell, *cell.Value.S)
+	}
+	// Output:tape: 3 cell: 0 value: added later
+}
+
+// BenchmarkUnsignedCells benchmarks the method UnsignedCells()
+func BenchmarkUnsignedCells(b *testing.B) {
+	tapes := parseScript(b, indexedScript(b, [][]byte{[]byte("hello world"), []byte("text/plain")}, "2"))
+	for i := 0; i < b.N; i++ {
+		_ = UnsignedCells(tapes)
+	}
+}
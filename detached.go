@@ -0,0 +1,151 @@
+package aip
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	bsm "github.com/bsv-blockchain/go-sdk/compat/bsm"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// Detached signature digests and armor
+const (
+	digestSHA256  = "sha256:"
+	detachedLabel = "AIP DETACHED SIGNATURE"
+)
+
+// DetachedSignature is an AIP signature kept apart from the file it signs (a sidecar)
+//
+// The signed message is the digest string ("sha256:" + hex SHA-256 of the file),
+// signed with Bitcoin Signed Message. No OP_RETURN is prepended.
+type DetachedSignature struct {
+	Algorithm                 Algorithm `json:"algorithm"`                   // Known AIP algorithm type
+	AlgorithmSigningComponent string    `json:"algorithm_signing_component"` // Address, or identity key for paymail
+	Digest                    string    `json:"digest"`                      // The signed file digest ("sha256:<hex>")
+	Signature                 string    `json:"signature"`                   // The signature (base64)
+}
+
+// SignDetached signs the file read from r and returns its detached signature
+func SignDetached(privateKey *ec.PrivateKey, algorithm Algorithm, r io.Reader) (*DetachedSignature, error) {
+	if privateKey == nil {
+		return nil, errors.New("private key is required")
+	}
+	if _, err := normalizeAlgorithm(algorithm, ModeStrict); err != nil {
+		return nil, err
+	}
+	digest, err := fileDigest(r)
+	if err != nil {
+		return nil, err
+	}
+	d := &DetachedSignature{Algorithm: algorithm, Digest: digest}
+	var sig []byte
+	if sig, err = bsm.SignMessage(privateKey, []byte(digest)); err != nil {
+		return nil, err
+	}
+	d.Signature = base64.StdEncoding.EncodeToString(sig)
+	if d.AlgorithmSigningComponent, err = signingComponent(privateKey, algorithm, Mainnet); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// VerifyDetached verifies the file read from r against its detached signature
+//
+// The AIP on the result holds the detached fields, with the digest as its data.
+func VerifyDetached(r io.Reader, d *DetachedSignature) (*ValidationResult, error) {
+	if d == nil {
+		return nil, errors.New("detached signature is required")
+	}
+	result := &ValidationResult{
+		Aip: &Aip{
+			Algorithm:                 d.Algorithm,
+			AlgorithmSigningComponent: d.AlgorithmSigningComponent,
+			Data:                      []string{d.Digest},
+			Signature:                 d.Signature,
+		},
+		Mode: ModeStrict,
+	}
+	algorithm, err := normalizeAlgorithm(d.Algorithm, ModeStrict)
+	if err != nil {
+		return result, err
+	}
+
+	var digest string
+	if digest, err = fileDigest(r); err != nil {
+		return result, err
+	} else if digest != d.Digest {
+		return result, fmt.Errorf("file digest (%s) does not match the signed digest (%s)", digest, d.Digest)
+	}
+
+	var sig []byte
+	if sig, err = base64.StdEncoding.DecodeString(d.Signature); err != nil {
+		return result, err
+	}
+	if result.Signer, result.Compressed, err = verifySigner(
+		algorithm, d.AlgorithmSigningComponent, sig, []byte(d.Digest),
+	); err != nil {
+		return result, err
+	}
+	result.Valid = true
+	return result, nil
+}
+
+// MarshalArmor returns the detached signature as armored text
+func (d *DetachedSignature) MarshalArmor() []byte {
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "-----BEGIN %s-----\n", detachedLabel)
+	_, _ = fmt.Fprintf(&buf, "Algorithm: %s\n", d.Algorithm)
+	_, _ = fmt.Fprintf(&buf, "Signing-Component: %s\n", d.AlgorithmSigningComponent)
+	_, _ = fmt.Fprintf(&buf, "Digest: %s\n", d.Digest)
+	_, _ = fmt.Fprintf(&buf, "Signature: %s\n", d.Signature)
+	_, _ = fmt.Fprintf(&buf, "-----END %s-----\n", detachedLabel)
+	return buf.Bytes()
+}
+
+// ParseDetachedSignature parses a sidecar in JSON or armored text
+func ParseDetachedSignature(data []byte) (*DetachedSignature, error) {
+	d := new(DetachedSignature)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, d); err != nil {
+			return nil, err
+		}
+	} else if err := d.unmarshalArmor(data); err != nil {
+		return nil, err
+	}
+	if len(d.Algorithm) == 0 || len(d.AlgorithmSigningComponent) == 0 || len(d.Digest) == 0 || len(d.Signature) == 0 {
+		return nil, errors.New("detached signature is missing fields")
+	}
+	return d, nil
+}
+
+// unmarshalArmor parses the armored text (line endings and surrounding whitespace are ignored)
+func (d *DetachedSignature) unmarshalArmor(data []byte) error {
+	lines, err := armorLines(string(data), "-----BEGIN "+detachedLabel+"-----", "-----END "+detachedLabel+"-----")
+	if err != nil {
+		return err
+	}
+	return armorHeaders(lines, map[string]*string{
+		"Algorithm":         (*string)(&d.Algorithm),
+		"Signing-Component": &d.AlgorithmSigningComponent,
+		"Digest":            &d.Digest,
+		"Signature":         &d.Signature,
+	})
+}
+
+// fileDigest returns the digest string of the file read from r
+func fileDigest(r io.Reader) (string, error) {
+	if r == nil {
+		return "", errors.New("file reader is required")
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return digestSHA256 + hex.EncodeToString(h.Sum(nil)), nil
+}
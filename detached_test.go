@@ -0,0 +1,173 @@
+package aip
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// exampleFile is the file content signed by the detached signature tests
+const exampleFile = "hello file\n"
+
+// TestSignDetached will test the methods SignDetached() and VerifyDetached()
+func TestSignDetached(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range []Algorithm{BitcoinECDSA, BitcoinSignedMessage, Paymail} {
+		d, err := SignDetached(examplePrivateKey, algorithm, strings.NewReader(exampleFile))
+		if err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), algorithm, err.Error())
+		} else if d.Digest != "sha256:702b7d2e4b28c4f3ef1434bd2333a83427796a9007fb2a23248becd4d51a3e7f" {
+			t.Errorf("%s Failed: [%s] unexpected digest [%s]", t.Name(), algorithm, d.Digest)
+		}
+
+		var result *ValidationResult
+		if result, err = VerifyDetached(strings.NewReader(exampleFile), d); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), algorithm, err.Error())
+		} else if !result.Valid || result.Signer != exampleAddress {
+			t.Errorf("%s Failed: [%s] expected signer [%s] but got [%s]", t.Name(), algorithm, exampleAddress, result.Signer)
+		}
+
+		// The signature is not an on-chain AIP signature
+		a := &Aip{Algorithm: d.Algorithm, AlgorithmSigningComponent: d.AlgorithmSigningComponent, Data: []string{opReturn, d.Digest}, Signature: d.Signature}
+		if valid, _ := a.Validate(); valid {
+			t.Errorf("%s Failed: [%s] detached signature should not validate as OP_RETURN data", t.Name(), algorithm)
+		}
+	}
+
+	if _, err := SignDetached(examplePrivateKey, "unknown", strings.NewReader(exampleFile)); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown algorithm)", t.Name())
+	}
+	if _, err := SignDetached(nil, BitcoinSignedMessage, strings.NewReader(exampleFile)); err == nil {
+		t.Errorf("%s Failed: error was expected (missing key)", t.Name())
+	}
+	if _, err := SignDetached(examplePrivateKey, BitcoinSignedMessage, nil); err == nil {
+		t.Errorf("%s Failed: error was expected (missing reader)", t.Name())
+	}
+}
+
+// TestVerifyDetached_Invalid will test the method VerifyDetached() with invalid signatures
+func TestVerifyDetached_Invalid(t *testing.T) {
+	t.Parallel()
+
+	d, err := SignDetached(examplePrivateKey, BitcoinSignedMessage, strings.NewReader(exampleFile))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name   string
+			file   string
+			modify func(d DetachedSignature) *DetachedSignature
+		}{
+			{"modified file", exampleFile + "x", func(d DetachedSignature) *DetachedSignature { return &d }},
+			{"other signer", exampleFile, func(d DetachedSignature) *DetachedSignature {
+				d.AlgorithmSigningComponent = address(secondPrivateKey)
+				return &d
+			}},
+			{"other digest", exampleFile + "x", func(d DetachedSignature) *DetachedSignature {
+				d.Digest = "sha256:f0b434276b6013f3a4e043f257f1617c6f597e4eb4047a27b4e30257d9cee906"
+				return &d
+			}},
+			{"unknown algorithm", exampleFile, func(d DetachedSignature) *DetachedSignature {
+				d.Algorithm = "unknown"
+				return &d
+			}},
+			{"invalid signature", exampleFile, func(d DetachedSignature) *DetachedSignature {
+				d.Signature = "invalid-sig"
+				return &d
+			}},
+			{"missing signature", exampleFile, func(DetachedSignature) *DetachedSignature { return nil }},
+		}
+	)
+
+	for _, test := range tests {
+		if result, err := VerifyDetached(strings.NewReader(test.file), test.modify(*d)); err == nil {
+			t.Errorf("%s Failed: [%s] error was expected", t.Name(), test.name)
+		} else if result != nil && result.Valid {
+			t.Errorf("%s Failed: [%s] should not be valid", t.Name(), test.name)
+		}
+	}
+}
+
+// TestParseDetachedSignature will test the method ParseDetachedSignature()
+func TestParseDetachedSignature(t *testing.T) {
+	t.Parallel()
+
+	d, err := SignDetached(examplePrivateKey, Paymail, strings.NewReader(exampleFile))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	armored := string(d.MarshalArmor())
+	var jsonSidecar []byte
+	if jsonSidecar, err = json.Marshal(d); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name          string
+			sidecar       string
+			expectedError bool
+		}{
+			{"json", string(jsonSidecar), false},
+			{"armored", armored, false},
+			{"armored (crlf)", strings.ReplaceAll(armored, "\n", "\r\n"), false},
+			{"armored (whitespace)", "\n\n  " + strings.ReplaceAll(armored, "\n", "  \n\t") + "\n\n", false},
+			{"armored (blank lines)", strings.Replace(armored, "\n", "\n\n", 2), false},
+			{"missing end", strings.Split(armored, "-----END")[0], true},
+			{"missing begin", strings.SplitN(armored, "\n", 2)[1], true},
+			{"text after end", armored + "extra\n", true},
+			{"unknown header", strings.Replace(armored, "Digest:", "Checksum:", 1), true},
+			{"duplicate header", strings.Replace(armored, "Digest:", "Algorithm:", 1), true},
+			{"missing field", strings.Replace(armored, "Signature: "+d.Signature+"\n", "", 1), true},
+			{"json missing field", `{"algorithm":"paymail"}`, true},
+			{"invalid json", `{"algorithm":`, true},
+			{"empty", "", true},
+		}
+	)
+
+	for _, test := range tests {
+		parsed, err := ParseDetachedSignature([]byte(test.sidecar))
+		if err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] error was expected", t.Name(), test.name)
+		} else if err == nil && *parsed != *d {
+			t.Errorf("%s Failed: [%s] expected [%v] but got [%v]", t.Name(), test.name, d, parsed)
+		} else if err == nil {
+			if _, err = VerifyDetached(bytes.NewBufferString(exampleFile), parsed); err != nil {
+				t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+			}
+		}
+	}
+}
+
+// ExampleSignDetached example using SignDetached()
+func ExampleSignDetached() {
+	d, err := SignDetached(examplePrivateKey, BitcoinSignedMessage, strings.NewReader(exampleFile))
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Print(string(d.MarshalArmor()))
+	// Output:-----BEGIN AIP DETACHED SIGNATURE-----
+	// Algorithm: BitcoinSignedMessage
+	// Signing-Component: 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK
+	// Digest: sha256:702b7d2e4b28c4f3ef1434bd2333a83427796a9007fb2a23248becd4d51a3e7f
+	// Signature: ICvHZ9v2/Zc7kGsUWwfioeVF/IGvcAH+LSKULkwaNWr8WOY1jddFB8cp/SPL0z1XB3c8KY1Wg77+G0TYvj8d+Q0=
+	// -----END AIP DETACHED SIGNATURE-----
+}
+
+// BenchmarkVerifyDetached benchmarks the method VerifyDetached()
+func BenchmarkVerifyDetached(b *testing.B) {
+	d, _ := SignDetached(examplePrivateKey, BitcoinSignedMessage, strings.NewReader(exampleFile))
+	for i := 0; i < b.N; i++ {
+		_, _ = VerifyDetached(strings.NewReader(exampleFile), d)
+	}
+}
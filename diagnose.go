@@ -0,0 +1,208 @@
+package aip
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/bitcoinschema/go-bpu"
+	bsm "github.com/bsv-blockchain/go-sdk/compat/bsm"
+)
+
+// Variant is a payload reconstruction, named by how it deviates from the spec
+// (deviations joined with "+", for example "no_op_return+no_pipe")
+type Variant string
+
+// Payload deviations found in historical signatures
+const (
+	VariantSpec       Variant = "spec"         // No deviation: the failure is not in the payload
+	VariantNoOpReturn Variant = "no_op_return" // The OP_RETURN ("j") is not signed
+	VariantNoPipe     Variant = "no_pipe"      // No pipe separators
+	VariantAllPipes   Variant = "all_pipes"    // Pipes between protocols signed too
+	VariantTrimmed    Variant = "trimmed"      // Whitespace trimmed from each cell
+	VariantHex        Variant = "hex"          // Each cell signed as its hex string
+	VariantUnhex      Variant = "unhex"        // Hex string cells signed as the bytes they encode
+)
+
+// payloadVariant is a reconstruction plus the transforms applied to its segments
+type payloadVariant struct {
+	name     Variant
+	r        reconstruction
+	opReturn bool
+	encoding Variant // Empty, VariantHex or VariantUnhex
+}
+
+// variants is the catalogue of reconstructions (the spec, then fewest deviations first)
+var variants = newVariantCatalogue()
+
+// newVariantCatalogue returns the spec and every combination of deviations
+func newVariantCatalogue() []payloadVariant {
+	var catalogue []payloadVariant
+	for _, opReturn := range []bool{true, false} {
+		for _, pipes := range []reconstruction{{pipes: true}, {pipes: true, allPipes: true}, {}} {
+			for _, trim := range []bool{false, true} {
+				for _, encoding := range []Variant{"", VariantHex, VariantUnhex} {
+					var deviations []string
+					if !opReturn {
+						deviations = append(deviations, string(VariantNoOpReturn))
+					}
+					if !pipes.pipes {
+						deviations = append(deviations, string(VariantNoPipe))
+					} else if pipes.allPipes {
+						deviations = append(deviations, string(VariantAllPipes))
+					}
+					if trim {
+						deviations = append(deviations, string(VariantTrimmed))
+					}
+					if encoding != "" {
+						deviations = append(deviations, string(encoding))
+					}
+					if len(deviations) == 0 {
+						deviations = append(deviations, string(VariantSpec))
+					}
+					catalogue = append(catalogue, payloadVariant{
+						name:     Variant(strings.Join(deviations, "+")),
+						r:        reconstruction{trim: trim, pipes: pipes.pipes, allPipes: pipes.allPipes},
+						opReturn: opReturn,
+						encoding: encoding,
+					})
+				}
+			}
+		}
+	}
+	sort.SliceStable(catalogue, func(i, j int) bool {
+		if catalogue[i].name == VariantSpec || catalogue[j].name == VariantSpec {
+			return catalogue[i].name == VariantSpec
+		}
+		return strings.Count(string(catalogue[i].name), "+") < strings.Count(string(catalogue[j].name), "+")
+	})
+	return catalogue
+}
+
+// payload rebuilds the message signed under the variant
+func (v payloadVariant) payload(tapes []bpu.Tape, a *Aip, instance int) ([]byte, bool) {
+	data, found := a.dataFromTapes(tapes, instance, v.r)
+	if !found {
+		return nil, false
+	}
+	if !v.opReturn {
+		data = data[1:]
+	}
+	var message []byte
+	for _, d := range data {
+		switch v.encoding {
+		case VariantHex:
+			message = append(message, hex.EncodeToString([]byte(d))...)
+		case VariantUnhex:
+			if b, err := hex.DecodeString(d); err == nil && len(d) > 0 {
+				message = append(message, b...)
+				continue
+			}
+			message = append(message, d...)
+		default:
+			message = append(message, d...)
+		}
+	}
+	return message, true
+}
+
+// Diagnosis is the alternative reconstruction search for a failed signature
+type Diagnosis struct {
+	Instance   int     `json:"instance"`             // The AIP instance (0 = first)
+	Claimed    string  `json:"claimed,omitempty"`    // The signer claimed by the signing component
+	Error      string  `json:"error"`                // Why the validation failed
+	Variant    Variant `json:"variant,omitempty"`    // The variant that recovers the claimed signer (empty = none)
+	Signer     string  `json:"signer,omitempty"`     // The address recovered with the variant
+	Compressed bool    `json:"compressed,omitempty"` // Whether the variant's signature references a compressed key
+	Tried      int     `json:"tried"`                // Number of distinct payloads tried
+}
+
+// Diagnose searches the catalogue of alternative payload reconstructions when validation fails
+//
+// It returns nil if the AIP validates. Otherwise the diagnosis reports the first
+// variant, built from the same tapes, that recovers the claimed signer. Variants
+// giving the same bytes as an earlier one are skipped, and "spec" means the
+// payload is fine and the failure is elsewhere (algorithm case, uncompressed key,
+// unexpected signer). The search needs a parsable AIP with a known algorithm (any
+// case) and signature, otherwise no variant is tried.
+func Diagnose(tapes []bpu.Tape, opts *ValidateOptions) *Diagnosis {
+	if opts == nil {
+		opts = &ValidateOptions{}
+	}
+	result, err := ValidateTapesWithOptions(tapes, opts)
+	if err == nil && result != nil && result.Valid {
+		return nil
+	}
+	d := &Diagnosis{Instance: opts.Instance}
+	if err != nil {
+		d.Error = err.Error()
+	}
+
+	tapeIndex := findAipTape(tapes, opts.Instance)
+	if tapeIndex < 0 {
+		return d
+	}
+	a := NewFromTape(tapes[tapeIndex])
+	algorithm, aErr := normalizeAlgorithm(a.Algorithm, ModeLegacy)
+	if aErr != nil {
+		return d
+	}
+	d.Claimed = expectedSigner(algorithm, a.AlgorithmSigningComponent)
+	sig, sErr := base64.StdEncoding.DecodeString(a.Signature)
+	if sErr != nil {
+		return d
+	}
+
+	tried := make(map[string]bool, len(variants))
+	for _, v := range variants {
+		message, found := v.payload(tapes, a, opts.Instance)
+		if !found {
+			return d
+		} else if tried[string(message)] {
+			continue
+		}
+		tried[string(message)] = true
+		d.Tried++
+		pubKey, compressed, rErr := bsm.PubKeyFromSignature(sig, message)
+		if rErr != nil {
+			continue
+		}
+		signer, vErr := verifyComponent(algorithm, a.AlgorithmSigningComponent, pubKey, compressed)
+		if vErr != nil {
+			continue
+		}
+		d.Variant, d.Signer, d.Compressed = v.name, signer, compressed
+		return d
+	}
+	return d
+}
+
+// QualityReport aggregates stream results into data-quality counts for an index
+type QualityReport struct {
+	Signatures  int             `json:"signatures"`         // Number of AIPs seen
+	Valid       int             `json:"valid"`              // Valid signatures
+	Invalid     int             `json:"invalid"`            // Invalid signatures
+	Recoverable int             `json:"recoverable"`        // Invalid signatures a variant recovers
+	Variants    map[Variant]int `json:"variants,omitempty"` // Recoverable signatures by variant
+}
+
+// Add counts the results
+func (q *QualityReport) Add(results ...*StreamResult) {
+	for _, r := range results {
+		q.Signatures++
+		if r.Valid {
+			q.Valid++
+			continue
+		}
+		q.Invalid++
+		if r.Variant == "" {
+			continue
+		}
+		q.Recoverable++
+		if q.Variants == nil {
+			q.Variants = make(map[Variant]int)
+		}
+		q.Variants[r.Variant]++
+	}
+}
@@ -0,0 +1,134 @@
+package aip
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bitcoinschema/go-bpu"
+	bsm "github.com/bsv-blockchain/go-sdk/compat/bsm"
+)
+
+// variantTapes signs the exact message (no OP_RETURN prepended) and stores it after the parts
+func variantTapes(t *testing.T, parts []string, message string) []bpu.Tape {
+	sig, err := bsm.SignMessageWithCompression(examplePrivateKey, []byte(message), true)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var data [][]byte
+	for _, part := range parts {
+		data = append(data, []byte(part))
+	}
+	return signedTapes(t, data, &Aip{
+		Algorithm:                 BitcoinECDSA,
+		AlgorithmSigningComponent: address(examplePrivateKey),
+		Signature:                 base64.StdEncoding.EncodeToString(sig),
+	})
+}
+
+// TestDiagnose will test the method Diagnose()
+func TestDiagnose(t *testing.T) {
+	t.Parallel()
+
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var bobInvalidData *bob.Tx
+	if bobInvalidData, err = bob.NewFromString(sampleInvalidBobTx); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	hello := []string{"hello", pipe}
+	hexed := hex.EncodeToString([]byte(opReturn + "hello" + pipe))
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name            string
+			inputTapes      []bpu.Tape
+			inputOptions    *ValidateOptions
+			expectedNil     bool
+			expectedVariant Variant
+			expectedTried   int
+		}{
+			{"valid", bobValidData.Out[0].Tape, nil, true, "", 0},
+			{"no op_return", variantTapes(t, hello, "hello"+pipe), nil, false, VariantNoOpReturn, -1},
+			{"no pipe", variantTapes(t, hello, opReturn+"hello"), nil, false, VariantNoPipe, -1},
+			{"trimmed", variantTapes(t, []string{" hello ", pipe}, opReturn+"hello"+pipe), nil, false, VariantTrimmed, -1},
+			{"trimmed legacy", variantTapes(t, []string{" hello ", pipe}, opReturn+"hello"+pipe), &ValidateOptions{Mode: ModeLegacy}, true, "", 0},
+			{"all pipes", variantTapes(t, []string{"hello", pipe, "SET", pipe}, opReturn+"hello"+pipe+"SET"+pipe), nil, false, VariantAllPipes, -1},
+			{"hex", variantTapes(t, hello, hexed), nil, false, VariantHex, -1},
+			{"unhex", variantTapes(t, []string{"68656c6c6f", pipe}, opReturn+"hello"+pipe), nil, false, VariantUnhex, -1},
+			{"combined", variantTapes(t, hello, "hello"), nil, false, VariantNoOpReturn + "+" + VariantNoPipe, -1},
+			{"mismatch", bobInvalidData.Out[0].Tape, nil, false, "", -1},
+			{"unknown algorithm", lowercaseTapes(t), nil, false, VariantSpec, 1},
+			{"uncompressed", uncompressedTapes(t), nil, false, VariantSpec, 1},
+			{"no aip", []bpu.Tape{*new(bpu.Tape)}, nil, false, "", 0},
+		}
+	)
+
+	for _, test := range tests {
+		d := Diagnose(test.inputTapes, test.inputOptions)
+		if d == nil {
+			if !test.expectedNil {
+				t.Errorf("%s Failed: [%s] expected a diagnosis", t.Name(), test.name)
+			}
+			continue
+		} else if test.expectedNil {
+			t.Errorf("%s Failed: [%s] expected no diagnosis but got [%v]", t.Name(), test.name, d)
+			continue
+		}
+		if d.Variant != test.expectedVariant {
+			t.Errorf("%s Failed: [%s] expected variant [%s] but got [%s]", t.Name(), test.name, test.expectedVariant, d.Variant)
+		} else if (test.expectedTried >= 0 && d.Tried != test.expectedTried) || (test.expectedTried < 0 && d.Tried == 0) {
+			t.Errorf("%s Failed: [%s] expected [%d] tried but got [%d]", t.Name(), test.name, test.expectedTried, d.Tried)
+		} else if d.Error == "" {
+			t.Errorf("%s Failed: [%s] expected the validation error", t.Name(), test.name)
+		} else if d.Variant != "" && d.Signer != d.Claimed {
+			t.Errorf("%s Failed: [%s] expected signer [%s] but got [%s]", t.Name(), test.name, d.Claimed, d.Signer)
+		}
+	}
+}
+
+// TestQualityReport will test the data-quality report of a diagnosed stream
+func TestQualityReport(t *testing.T) {
+	t.Parallel()
+
+	report := new(QualityReport)
+	if _, err := ValidateRawTxs(bytes.NewReader(loadTestFile(t, "txs.hex")), &bytes.Buffer{},
+		&StreamOptions{Diagnose: true, Report: report}); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	if report.Signatures != 9 || report.Valid != 8 || report.Invalid != 1 || report.Recoverable != 0 {
+		t.Errorf("%s Failed: expected [9 8 1 0] but got [%+v]", t.Name(), report)
+	}
+
+	report = new(QualityReport)
+	report.Add(&StreamResult{Valid: true}, &StreamResult{Variant: VariantNoPipe}, &StreamResult{Variant: VariantNoPipe}, &StreamResult{})
+	if report.Signatures != 4 || report.Invalid != 3 || report.Recoverable != 2 || report.Variants[VariantNoPipe] != 2 {
+		t.Errorf("%s Failed: unexpected report [%+v]", t.Name(), report)
+	}
+}
+
+// ExampleDiagnose example using Diagnose()
+func ExampleDiagnose() {
+	bobInvalidData, err := bob.NewFromString(sampleInvalidBobTx)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	d := Diagnose(bobInvalidData.Out[0].Tape, nil)
+	fmt.Printf("recovered by a variant: %t", d.Variant != "")
+	// Output:recovered by a variant: false
+}
+
+// BenchmarkDiagnose benchmarks the method Diagnose() (a failure searching every variant)
+func BenchmarkDiagnose(b *testing.B) {
+	bobInvalidData, _ := bob.NewFromString(sampleInvalidBobTx)
+	for i := 0; i < b.N; i++ {
+		_ = Diagnose(bobInvalidData.Out[0].Tape, nil)
+	}
+}
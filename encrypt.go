@@ -0,0 +1,209 @@
+package aip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/bitcoinschema/go-bpu"
+	ecies "github.com/bsv-blockchain/go-sdk/compat/ecies"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// EncryptionMode is the order in which a payload is signed and encrypted
+//
+// The plaintext is the pushes of NewSignedOutput (segments separated by pipes,
+// without OP_FALSE OP_RETURN) encrypted with ECIES (Electrum, ephemeral key) for
+// the recipient. A sign-then-encrypt payload proves who wrote the plaintext, not
+// who it was sent to: the recipient can re-encrypt it for someone else.
+type EncryptionMode string
+
+// Encryption modes
+const (
+	SignThenEncrypt EncryptionMode = "sign_then_encrypt" // The AIP is encrypted with the content: only the recipient learns the author
+	EncryptThenSign EncryptionMode = "encrypt_then_sign" // The AIP signs the ciphertext: anyone can verify who published it, not who wrote it
+)
+
+// DecryptedOutput is the plaintext of an encrypted output and who signed it
+//
+// With sign-then-encrypt the signer wrote the plaintext. With encrypt-then-sign
+// the signer only published the ciphertext: anyone can sign a ciphertext they
+// copied, so it says nothing about who wrote the plaintext.
+type DecryptedOutput struct {
+	Signer     string            `json:"signer,omitempty"` // The address that signed (valid signatures only)
+	Mode       EncryptionMode    `json:"mode"`             // The encryption mode the output was decrypted with
+	Segments   [][][]byte        `json:"segments"`         // The decrypted protocol segments
+	Validation *ValidationResult `json:"validation"`       // The validation of the AIP signature
+}
+
+// NewEncryptedOutput signs and encrypts the protocol segments for the recipient
+//
+// Sign-then-encrypt outputs are: OP_FALSE OP_RETURN <ciphertext>, where the
+// ciphertext holds the segments and the AIP. Encrypt-then-sign outputs are:
+// OP_FALSE OP_RETURN <ciphertext> | <AIP>, where the ciphertext holds the segments.
+func NewEncryptedOutput(privateKey *ec.PrivateKey, algorithm Algorithm, recipient *ec.PublicKey,
+	mode EncryptionMode, segments ...[][]byte) (*transaction.TransactionOutput, *Aip, error) {
+
+	if recipient == nil {
+		return nil, nil, errors.New("recipient public key is required")
+	}
+
+	switch mode {
+	case SignThenEncrypt:
+		output, a, err := NewSignedOutput(privateKey, algorithm, segments...)
+		if err != nil {
+			return nil, nil, err
+		}
+		var ciphertext []byte
+		if ciphertext, err = encryptScript(envelopeData(output.LockingScript), recipient); err != nil {
+			return nil, nil, err
+		}
+		var s *script.Script
+		if s, err = NewEnvelopeScript(EnvelopeOpFalseOpReturn, nil, [][]byte{ciphertext}); err != nil {
+			return nil, nil, err
+		}
+		return &transaction.TransactionOutput{LockingScript: s}, a, nil
+	case EncryptThenSign:
+		pushes, _, err := segmentPushes(segments)
+		if err != nil {
+			return nil, nil, err
+		}
+		plaintext := &script.Script{}
+		if err = plaintext.AppendPushDataArray(pushes[:len(pushes)-1]); err != nil {
+			return nil, nil, err
+		}
+		var ciphertext []byte
+		if ciphertext, err = encryptScript(plaintext, recipient); err != nil {
+			return nil, nil, err
+		}
+		return NewSignedOutput(privateKey, algorithm, [][]byte{ciphertext})
+	}
+	return nil, nil, fmt.Errorf("unknown encryption mode: %s", mode)
+}
+
+// DecryptOutput decrypts an output made by NewEncryptedOutput in the expected mode and verifies its signer
+//
+// The mode is never guessed from the output, since anyone can add a visible AIP
+// to a ciphertext. The signature is validated in strict mode, Signer is only
+// set when it is valid.
+func DecryptOutput(recipient *ec.PrivateKey, lockingScript *script.Script, mode EncryptionMode) (*DecryptedOutput, error) {
+	if recipient == nil {
+		return nil, errors.New("recipient private key is required")
+	} else if lockingScript == nil {
+		return nil, errors.New("locking script is required")
+	} else if mode != SignThenEncrypt && mode != EncryptThenSign {
+		return nil, fmt.Errorf("unknown encryption mode: %s", mode)
+	}
+	tapes, err := TapesFromScript(lockingScript)
+	if err != nil {
+		return nil, err
+	}
+	pushes := dataPushes(tapes)
+	if len(pushes) == 0 {
+		return nil, errors.New("no encrypted data found")
+	}
+
+	// Encrypt-then-sign: the visible AIP signs the ciphertext (the first push)
+	result := &DecryptedOutput{Mode: mode}
+	signed := tapes
+	if visible := findAipTape(tapes, 0) >= 0; visible != (mode == EncryptThenSign) {
+		return nil, fmt.Errorf("output is not %s: visible AIP %t", mode, visible)
+	}
+
+	var plaintext *script.Script
+	if plaintext, err = decryptScript(pushes[0], recipient); err != nil {
+		return nil, err
+	}
+	var plainTapes []bpu.Tape
+	if plainTapes, err = TapesFromScript(plaintext); err != nil {
+		return nil, err
+	}
+	if result.Mode == SignThenEncrypt {
+		signed = plainTapes
+	}
+	result.Segments = segmentsFromPushes(dataPushes(plainTapes))
+
+	if result.Validation, err = ValidateTapesWithOptions(signed, &ValidateOptions{Mode: ModeStrict}); err != nil {
+		return result, err
+	}
+	result.Signer = result.Validation.Signer
+	return result, nil
+}
+
+// envelopeData returns the script without the leading OP_FALSE OP_RETURN
+func envelopeData(s *script.Script) *script.Script {
+	data := script.Script((*s)[2:])
+	return &data
+}
+
+// encryptScript encrypts the serialized pushes for the recipient
+func encryptScript(s *script.Script, recipient *ec.PublicKey) ([]byte, error) {
+	return ecies.ElectrumEncrypt(*s, recipient, nil, false)
+}
+
+// decryptScript decrypts the ciphertext and returns OP_FALSE OP_RETURN followed by the plaintext pushes
+func decryptScript(ciphertext []byte, recipient *ec.PrivateKey) (*script.Script, error) {
+	plaintext, err := ecies.ElectrumDecrypt(ciphertext, recipient, nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &script.Script{}
+	_ = s.AppendOpcodes(script.OpFALSE, script.OpRETURN)
+	*s = append(*s, plaintext...)
+	if _, err = s.Chunks(); err != nil {
+		return nil, fmt.Errorf("invalid plaintext: %w", err)
+	}
+	return s, nil
+}
+
+// dataPushes returns the data cells after the envelope, up to the first AIP
+func dataPushes(tapes []bpu.Tape) [][]byte {
+	_, startTape, startCell := envelopeStart(tapes)
+	var pushes [][]byte
+	for i, tape := range tapes {
+		found := false
+		for j, cell := range tape.Cell {
+			if i < startTape || (i == startTape && j < startCell) {
+				continue
+			}
+			if cell.S != nil && *cell.S == Prefix {
+				return pushes
+			}
+			if cell.Op != nil && (*cell.Op == 0 || *cell.Op > 0x4e) {
+				continue
+			}
+			if cell.S != nil {
+				pushes = append(pushes, []byte(*cell.S))
+				found = true
+			}
+		}
+
+		// Tapes are split at pipes, which are not cells
+		if found {
+			pushes = append(pushes, []byte(pipe))
+		}
+	}
+	return pushes
+}
+
+// segmentsFromPushes splits the pushes into segments at each pipe
+func segmentsFromPushes(pushes [][]byte) [][][]byte {
+	var segments [][][]byte
+	var segment [][]byte
+	for _, push := range pushes {
+		if bytes.Equal(push, []byte(pipe)) {
+			if len(segment) > 0 {
+				segments = append(segments, segment)
+			}
+			segment = nil
+			continue
+		}
+		segment = append(segment, push)
+	}
+	if len(segment) > 0 {
+		segments = append(segments, segment)
+	}
+	return segments
+}
@@ -0,0 +1,158 @@
+package aip
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// encryptedSegments are the B and MAP segments used by the encryption tests
+var encryptedSegments = [][][]byte{
+	{[]byte("19HxigV4QyBv3tHpQVcUEQyq1pzZVdoAut"), []byte("private note"), []byte("text/plain")},
+	{[]byte(MapPrefix), []byte("SET"), []byte("app"), []byte("example")},
+}
+
+// TestNewEncryptedOutput will test the methods NewEncryptedOutput() and DecryptOutput()
+func TestNewEncryptedOutput(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []EncryptionMode{SignThenEncrypt, EncryptThenSign} {
+		for _, algorithm := range []Algorithm{BitcoinSignedMessage, Paymail} {
+			output, a, err := NewEncryptedOutput(examplePrivateKey, algorithm, secondPrivateKey.PubKey(), mode, encryptedSegments...)
+			if err != nil {
+				t.Fatalf("%s Failed: [%s] [%s] error not expected but got: %s", t.Name(), mode, algorithm, err.Error())
+			}
+
+			// The plaintext is never visible on-chain
+			if bytes.Contains(*output.LockingScript, []byte("private note")) {
+				t.Errorf("%s Failed: [%s] plaintext found in the output", t.Name(), mode)
+			}
+			visible := bytes.Contains(*output.LockingScript, []byte(Prefix))
+			if visible != (mode == EncryptThenSign) {
+				t.Errorf("%s Failed: [%s] expected visible AIP [%t] but got [%t]", t.Name(), mode, mode == EncryptThenSign, visible)
+			}
+
+			var decrypted *DecryptedOutput
+			if decrypted, err = DecryptOutput(secondPrivateKey, output.LockingScript, mode); err != nil {
+				t.Fatalf("%s Failed: [%s] [%s] error not expected but got: %s", t.Name(), mode, algorithm, err.Error())
+			} else if decrypted.Mode != mode {
+				t.Errorf("%s Failed: expected mode [%s] but got [%s]", t.Name(), mode, decrypted.Mode)
+			} else if decrypted.Signer != exampleAddress || !decrypted.Validation.Valid {
+				t.Errorf("%s Failed: [%s] [%s] expected signer [%s] but got [%s]", t.Name(), mode, algorithm, exampleAddress, decrypted.Signer)
+			} else if fmt.Sprintf("%q", decrypted.Segments) != fmt.Sprintf("%q", encryptedSegments) {
+				t.Errorf("%s Failed: [%s] expected segments %q but got %q", t.Name(), mode, encryptedSegments, decrypted.Segments)
+			} else if a.Signature != decrypted.Validation.Aip.Signature {
+				t.Errorf("%s Failed: [%s] expected signature [%s] but got [%s]", t.Name(), mode, a.Signature, decrypted.Validation.Aip.Signature)
+			}
+
+			// Only the recipient can decrypt
+			if _, err = DecryptOutput(thirdPrivateKey, output.LockingScript, mode); err == nil {
+				t.Errorf("%s Failed: [%s] error was expected (wrong recipient)", t.Name(), mode)
+			}
+
+			// The output is only accepted in the mode it was made with
+			other := SignThenEncrypt
+			if mode == SignThenEncrypt {
+				other = EncryptThenSign
+			}
+			if _, err = DecryptOutput(secondPrivateKey, output.LockingScript, other); err == nil {
+				t.Errorf("%s Failed: [%s] error was expected (decrypted as %s)", t.Name(), mode, other)
+			}
+		}
+	}
+}
+
+// TestNewEncryptedOutput_Invalid will test the errors of NewEncryptedOutput() and DecryptOutput()
+func TestNewEncryptedOutput_Invalid(t *testing.T) {
+	t.Parallel()
+
+	recipient := secondPrivateKey.PubKey()
+	if _, _, err := NewEncryptedOutput(examplePrivateKey, BitcoinSignedMessage, nil, SignThenEncrypt, encryptedSegments...); err == nil {
+		t.Errorf("%s Failed: error was expected (missing recipient)", t.Name())
+	}
+	if _, _, err := NewEncryptedOutput(examplePrivateKey, BitcoinSignedMessage, recipient, "compress_then_sign", encryptedSegments...); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown mode)", t.Name())
+	}
+	for _, mode := range []EncryptionMode{SignThenEncrypt, EncryptThenSign} {
+		if _, _, err := NewEncryptedOutput(examplePrivateKey, BitcoinSignedMessage, recipient, mode); err == nil {
+			t.Errorf("%s Failed: [%s] error was expected (no segments)", t.Name(), mode)
+		}
+	}
+
+	// A tampered ciphertext no longer decrypts
+	output, _, err := NewEncryptedOutput(examplePrivateKey, BitcoinSignedMessage, recipient, EncryptThenSign, encryptedSegments...)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	tampered := append(script.Script{}, *output.LockingScript...)
+	tampered[10] ^= 0xff
+	if _, err = DecryptOutput(secondPrivateKey, &tampered, EncryptThenSign); err == nil {
+		t.Errorf("%s Failed: error was expected (tampered ciphertext)", t.Name())
+	}
+
+	// Outputs without encrypted data
+	plain, _, _ := NewSignedOutput(examplePrivateKey, BitcoinSignedMessage, encryptedSegments...)
+	empty, _ := NewEnvelopeScript(EnvelopeOpFalseOpReturn, nil, nil)
+	for _, s := range []*script.Script{nil, plain.LockingScript, empty} {
+		if _, err = DecryptOutput(secondPrivateKey, s, SignThenEncrypt); err == nil {
+			t.Errorf("%s Failed: [%v] inputted and error was expected", t.Name(), s)
+		}
+	}
+	if _, err = DecryptOutput(nil, output.LockingScript, EncryptThenSign); err == nil {
+		t.Errorf("%s Failed: error was expected (missing recipient)", t.Name())
+	}
+	if _, err = DecryptOutput(secondPrivateKey, output.LockingScript, "compress_then_sign"); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown mode)", t.Name())
+	}
+
+	// A sign-then-encrypt ciphertext with a visible AIP added by someone else
+	stolen, _, err := NewEncryptedOutput(examplePrivateKey, BitcoinSignedMessage, recipient, SignThenEncrypt, encryptedSegments...)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	chunks, _ := stolen.LockingScript.Chunks()
+	var resigned *transaction.TransactionOutput
+	if resigned, _, err = NewSignedOutput(thirdPrivateKey, BitcoinSignedMessage, [][]byte{chunks[2].Data}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	if _, err = DecryptOutput(secondPrivateKey, resigned.LockingScript, SignThenEncrypt); err == nil {
+		t.Errorf("%s Failed: error was expected (visible AIP on a sign-then-encrypt output)", t.Name())
+	}
+	var decrypted *DecryptedOutput
+	if decrypted, err = DecryptOutput(secondPrivateKey, resigned.LockingScript, EncryptThenSign); err != nil {
+		t.Errorf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if decrypted.Signer != address(thirdPrivateKey) {
+		t.Errorf("%s Failed: expected the publisher [%s] but got [%s]", t.Name(), address(thirdPrivateKey), decrypted.Signer)
+	}
+}
+
+// ExampleNewEncryptedOutput example using NewEncryptedOutput()
+func ExampleNewEncryptedOutput() {
+	output, _, err := NewEncryptedOutput(examplePrivateKey, BitcoinSignedMessage, secondPrivateKey.PubKey(),
+		SignThenEncrypt, [][]byte{[]byte("19HxigV4QyBv3tHpQVcUEQyq1pzZVdoAut"), []byte("hello"), []byte("text/plain")},
+	)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	var decrypted *DecryptedOutput
+	if decrypted, err = DecryptOutput(secondPrivateKey, output.LockingScript, SignThenEncrypt); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("signer: %s content: %s", decrypted.Signer, decrypted.Segments[0][1])
+	// Output:signer: 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK content: hello
+}
+
+// BenchmarkDecryptOutput benchmarks the method DecryptOutput()
+func BenchmarkDecryptOutput(b *testing.B) {
+	output, _, _ := NewEncryptedOutput(examplePrivateKey, BitcoinSignedMessage, secondPrivateKey.PubKey(),
+		SignThenEncrypt, encryptedSegments...)
+	for i := 0; i < b.N; i++ {
+		_, _ = DecryptOutput(secondPrivateKey, output.LockingScript, SignThenEncrypt)
+	}
+}
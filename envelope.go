@@ -0,0 +1,130 @@
+package aip
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bitcoinschema/go-bpu"
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// Envelope is the script form that carries the signed data
+//
+// For every envelope the signed payload starts with OP_RETURN ("j") followed by
+// the pushes after the OP_RETURN opcode. Anything before OP_RETURN (OP_FALSE, a
+// spendable locking script, an ordinal inscription) is never signed.
+//
+// Outputs without an OP_RETURN but with an inscription (OP_FALSE OP_IF ... OP_ENDIF)
+// use EnvelopeInscription: the payload starts with OP_RETURN ("j") followed by every
+// push from the start of the inscription ("ord", content type, content) and after
+// OP_ENDIF (pipes included, as pushed) up to the AIP prefix. The locking script
+// before the inscription is never signed.
+type Envelope string
+
+// Envelope forms
+const (
+	EnvelopeNone            Envelope = ""                   // No OP_RETURN or inscription found
+	EnvelopeOpFalseOpReturn Envelope = "op_false_op_return" // OP_FALSE OP_RETURN <data> (modern, unspendable)
+	EnvelopeOpReturn        Envelope = "op_return"          // OP_RETURN <data> (legacy, bare)
+	EnvelopeLockingScript   Envelope = "locking_script"     // <locking script> OP_RETURN <data> (spendable)
+	EnvelopeInscription     Envelope = "inscription"        // <locking script> OP_FALSE OP_IF ... OP_ENDIF | <data> (spendable)
+)
+
+// opReturnOp is the OP_RETURN opcode as found in BOB cells
+var opReturnOp = script.OpRETURN
+
+// DetectEnvelope returns the envelope used by the given output tapes
+func DetectEnvelope(tapes []bpu.Tape) Envelope {
+	envelope, _, _ := envelopeStart(tapes)
+	return envelope
+}
+
+// DetectScriptEnvelope returns the envelope used by the given script
+func DetectScriptEnvelope(s *script.Script) (Envelope, error) {
+	chunks, err := s.Chunks()
+	if err != nil {
+		return EnvelopeNone, err
+	}
+	inscription := false
+	for i, chunk := range chunks {
+		if chunk.Op == opReturnOp {
+			return envelopeFromPrefix(i, i == 1 && chunks[0].Op == script.OpFALSE), nil
+		}
+		if i > 0 && chunk.Op == script.OpIF && chunks[i-1].Op == script.OpFALSE {
+			inscription = true
+		}
+	}
+	if inscription {
+		return EnvelopeInscription, nil
+	}
+	return EnvelopeNone, nil
+}
+
+// NewEnvelopeScript builds a script of the given envelope form from data pushes
+//
+// The locking script is only used (and required) by EnvelopeLockingScript
+func NewEnvelopeScript(envelope Envelope, lockingScript *script.Script, pushes [][]byte) (*script.Script, error) {
+	s := &script.Script{}
+	switch envelope {
+	case EnvelopeOpFalseOpReturn:
+		_ = s.AppendOpcodes(script.OpFALSE, script.OpRETURN)
+	case EnvelopeOpReturn:
+		_ = s.AppendOpcodes(script.OpRETURN)
+	case EnvelopeLockingScript:
+		if lockingScript == nil || len(*lockingScript) == 0 {
+			return nil, errors.New("locking script is required")
+		}
+		*s = append(*s, *lockingScript...)
+		_ = s.AppendOpcodes(script.OpRETURN)
+	default:
+		return nil, fmt.Errorf("unknown envelope: %s", envelope)
+	}
+	if err := s.AppendPushDataArray(pushes); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// envelopeStart returns the envelope and the position of the first signed data cell
+func envelopeStart(tapes []bpu.Tape) (Envelope, int, int) {
+	position := 0
+	var falsePrefix, previousFalse bool
+	inscriptionTape, inscriptionCell := -1, -1
+	for i, tape := range tapes {
+		for j, cell := range tape.Cell {
+			if isOp(cell, opReturnOp) {
+				envelope := envelopeFromPrefix(position, position == 1 && falsePrefix)
+				return envelope, i, j + 1
+			}
+			if position == 0 && isOp(cell, script.OpFALSE) {
+				falsePrefix = true
+			}
+			if previousFalse && isOp(cell, script.OpIF) && inscriptionTape < 0 {
+				inscriptionTape, inscriptionCell = i, j+1
+			}
+			previousFalse = isOp(cell, script.OpFALSE)
+			position++
+		}
+	}
+	if inscriptionTape >= 0 {
+		return EnvelopeInscription, inscriptionTape, inscriptionCell
+	}
+	return EnvelopeNone, 0, 0
+}
+
+// isOp returns true if the cell is the given opcode
+func isOp(cell bpu.Cell, op byte) bool {
+	return cell.Op != nil && *cell.Op == op
+}
+
+// envelopeFromPrefix returns the envelope given the number of chunks before OP_RETURN
+func envelopeFromPrefix(count int, falsePrefix bool) Envelope {
+	switch {
+	case count == 0:
+		return EnvelopeOpReturn
+	case falsePrefix:
+		return EnvelopeOpFalseOpReturn
+	default:
+		return EnvelopeLockingScript
+	}
+}
@@ -0,0 +1,143 @@
+package aip
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+)
+
+// envelopeFixture is a signed transaction using one of the envelope forms
+type envelopeFixture struct {
+	Description string   `json:"description"`
+	Envelope    Envelope `json:"envelope"`
+	Signer      string   `json:"signer"`
+	Tx          string   `json:"tx"`
+}
+
+// loadEnvelopeFixtures loads testdata/envelopes.json or fails the test
+func loadEnvelopeFixtures(t *testing.T) []envelopeFixture {
+	raw, err := os.ReadFile("testdata/envelopes.json")
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var fixtures []envelopeFixture
+	if err = json.Unmarshal(raw, &fixtures); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return fixtures
+}
+
+// TestDetectEnvelope will test the methods DetectEnvelope() and DetectScriptEnvelope()
+func TestDetectEnvelope(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range loadEnvelopeFixtures(t) {
+		tx, err := transaction.NewTransactionFromHex(f.Tx)
+		if err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), f.Description, err.Error())
+		}
+		var envelope Envelope
+		if envelope, err = DetectScriptEnvelope(tx.Outputs[0].LockingScript); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), f.Description, err.Error())
+		} else if envelope != f.Envelope {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%s]", t.Name(), f.Description, f.Envelope, envelope)
+		}
+
+		var bobTx *bob.Tx
+		if bobTx, err = bob.NewFromTx(tx); err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), f.Description, err.Error())
+		}
+		if envelope = DetectEnvelope(bobTx.Out[0].Tape); envelope != f.Envelope {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%s]", t.Name(), f.Description, f.Envelope, envelope)
+		}
+	}
+
+	// P2PKH without any data
+	addr, _ := script.NewAddressFromPublicKey(examplePrivateKey.PubKey(), true)
+	lock, _ := p2pkh.Lock(addr)
+	if envelope, err := DetectScriptEnvelope(lock); err != nil || envelope != EnvelopeNone {
+		t.Errorf("%s Failed: expected no envelope but got [%s] [%v]", t.Name(), envelope, err)
+	}
+	invalid := script.Script([]byte{script.OpPUSHDATA1, 0x05})
+	if _, err := DetectScriptEnvelope(&invalid); err == nil {
+		t.Errorf("%s Failed: error was expected (invalid script)", t.Name())
+	}
+}
+
+// TestEnvelope_Validate will test validation for each envelope fixture
+func TestEnvelope_Validate(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range loadEnvelopeFixtures(t) {
+		bobTx, err := bob.NewFromRawTxString(f.Tx)
+		if err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), f.Description, err.Error())
+		}
+		tapes := bobTx.Out[0].Tape
+
+		if valid, err := ValidateTapes(tapes); !valid {
+			t.Errorf("%s Failed: [%s] validation should have passed, error: %v", t.Name(), f.Description, err)
+		}
+
+		var result *ValidationResult
+		if result, err = ValidateTapesWithOptions(tapes, &ValidateOptions{Mode: ModeStrict}); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), f.Description, err.Error())
+		} else if result.Envelope != f.Envelope {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%s]", t.Name(), f.Description, f.Envelope, result.Envelope)
+		} else if result.Signer != f.Signer {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%s]", t.Name(), f.Description, f.Signer, result.Signer)
+		}
+	}
+}
+
+// TestNewEnvelopeScript will test the method NewEnvelopeScript()
+func TestNewEnvelopeScript(t *testing.T) {
+	t.Parallel()
+
+	lock := &script.Script{}
+	_ = lock.AppendOpcodes(script.OpTRUE)
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			inputEnvelope  Envelope
+			inputLocking   *script.Script
+			expectedScript string
+			expectedError  bool
+		}{
+			{EnvelopeOpFalseOpReturn, nil, "006a0474657374", false},
+			{EnvelopeOpReturn, nil, "6a0474657374", false},
+			{EnvelopeLockingScript, lock, "516a0474657374", false},
+			{EnvelopeLockingScript, nil, "", true},
+			{EnvelopeNone, nil, "", true},
+		}
+	)
+
+	for _, test := range tests {
+		if s, err := NewEnvelopeScript(test.inputEnvelope, test.inputLocking, [][]byte{[]byte("test")}); err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.inputEnvelope, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] error was expected", t.Name(), test.inputEnvelope)
+		} else if s != nil && s.String() != test.expectedScript {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%s]", t.Name(), test.inputEnvelope, test.expectedScript, s.String())
+		}
+	}
+}
+
+// ExampleNewEnvelopeScript example using NewEnvelopeScript()
+func ExampleNewEnvelopeScript() {
+	s, err := NewEnvelopeScript(EnvelopeOpReturn, nil, [][]byte{[]byte("test")})
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	envelope, _ := DetectScriptEnvelope(s)
+	fmt.Printf("script: %s envelope: %s", s.String(), envelope)
+	// Output:script: 6a0474657374 envelope: op_return
+}
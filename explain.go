@@ -0,0 +1,238 @@
+package aip
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bitcoinschema/go-bpu"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// bsmMagic is the prefix hashed with every Bitcoin Signed Message
+const bsmMagic = "Bitcoin Signed Message:\n"
+
+// Segment is a piece of a reconstructed payload
+type Segment struct {
+	Hex  string `json:"hex"`  // The bytes (hex)
+	Text string `json:"text"` // The bytes as UTF-8 (invalid sequences replaced)
+}
+
+// ExplainAttempt is one payload interpretation tried while validating
+type ExplainAttempt struct {
+	Interpretation      Interpretation `json:"interpretation"`                 // The payload interpretation
+	Segments            []Segment      `json:"segments,omitempty"`             // Every reconstructed Data segment
+	MessageHex          string         `json:"message_hex,omitempty"`          // The exact message bytes (hex)
+	MessageHash         string         `json:"message_hash,omitempty"`         // The BSM hash of the message (hex)
+	RecoveredPubKey     string         `json:"recovered_pubkey,omitempty"`     // The public key recovered from the signature (hex)
+	Compressed          bool           `json:"compressed"`                     // Whether the signature references the compressed key
+	CompressedAddress   string         `json:"compressed_address,omitempty"`   // Address of the compressed recovered key
+	UncompressedAddress string         `json:"uncompressed_address,omitempty"` // Address of the uncompressed recovered key
+	FailedStep          Stage          `json:"failed_step,omitempty"`          // The step that failed (empty = matched)
+	Error               string         `json:"error,omitempty"`                // Why the step failed
+}
+
+// Explanation is a step by step report of the validation of one AIP
+type Explanation struct {
+	Mode                      Mode              `json:"mode"`                                  // The validation mode
+	Instance                  int               `json:"instance"`                              // Which AIP in the tapes
+	Prefix                    *CellRef          `json:"prefix,omitempty"`                      // Where the AIP prefix was found
+	Algorithm                 Algorithm         `json:"algorithm,omitempty"`                   // Parsed algorithm
+	AlgorithmSigningComponent string            `json:"algorithm_signing_component,omitempty"` // Parsed signing component
+	Signature                 string            `json:"signature,omitempty"`                   // Parsed signature
+	Indices                   []int             `json:"indices,omitempty"`                     // Parsed BOB indices
+	ExpectedSigner            string            `json:"expected_signer,omitempty"`             // Address the signer must match
+	Attempts                  []*ExplainAttempt `json:"attempts,omitempty"`                    // Every payload interpretation tried
+	Valid                     bool              `json:"valid"`                                 // The validation result
+	Signer                    string            `json:"signer,omitempty"`                      // Recovered signer if valid
+	Interpretation            Interpretation    `json:"interpretation,omitempty"`              // The interpretation that matched
+	FailedStep                Stage             `json:"failed_step,omitempty"`                 // The step that failed
+	Error                     string            `json:"error,omitempty"`                       // The validation error
+}
+
+// explainObserver records the stages of a validation for Explain
+type explainObserver struct {
+	NopObserver
+	attempts []*ExplainAttempt
+	failed   Stage
+	pubKeys  []*ec.PublicKey
+}
+
+// StartStage implements Observer
+func (o *explainObserver) StartStage(ctx context.Context, stage Stage) (context.Context, func(error)) {
+	return ctx, func(err error) {
+		if err == nil {
+			return
+		}
+		o.failed = stage
+		if stage != StageParse && len(o.attempts) > 0 {
+			attempt := o.attempts[len(o.attempts)-1]
+			attempt.FailedStep, attempt.Error = stage, err.Error()
+		}
+	}
+}
+
+// attempt implements attemptObserver
+func (o *explainObserver) attempt(name Interpretation) {
+	o.attempts = append(o.attempts, &ExplainAttempt{Interpretation: name})
+	o.pubKeys = append(o.pubKeys, nil)
+}
+
+// payload implements attemptObserver
+func (o *explainObserver) payload(data []string) {
+	attempt := o.attempts[len(o.attempts)-1]
+	for _, d := range data {
+		attempt.Segments = append(attempt.Segments, Segment{
+			Hex:  hex.EncodeToString([]byte(d)),
+			Text: strings.ToValidUTF8(d, "�"),
+		})
+	}
+	message := []byte(strings.Join(data, ""))
+	attempt.MessageHex = hex.EncodeToString(message)
+	attempt.MessageHash = hex.EncodeToString(bsmHash(message))
+}
+
+// recovered implements attemptObserver
+func (o *explainObserver) recovered(pubKey *ec.PublicKey, compressed bool) {
+	attempt := o.attempts[len(o.attempts)-1]
+	attempt.Compressed = compressed
+	if compressed {
+		attempt.RecoveredPubKey = hex.EncodeToString(pubKey.Compressed())
+	} else {
+		attempt.RecoveredPubKey = hex.EncodeToString(pubKey.Uncompressed())
+	}
+	o.pubKeys[len(o.pubKeys)-1] = pubKey
+}
+
+// Explain validates one AIP (like ValidateTapesWithOptions) and reports every step
+//
+// The attempts are recorded while ValidateTapesWithOptions runs, so they show
+// exactly the data behind its verdict, signer and error.
+func Explain(tapes []bpu.Tape, opts *ValidateOptions) *Explanation {
+	o := ValidateOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	observer := new(explainObserver)
+	o.Observer = observer
+	result, err := ValidateTapesWithOptions(tapes, &o)
+
+	e := &Explanation{Mode: o.Mode, Instance: o.Instance, Attempts: observer.attempts}
+	if e.Mode == "" {
+		e.Mode = ModeStrict
+	}
+	if err != nil {
+		e.Error = err.Error()
+		e.FailedStep = observer.failed
+	}
+	if result == nil {
+		return e
+	}
+	e.Valid = result.Valid
+	e.Signer = result.Signer
+	e.Interpretation = result.Interpretation
+
+	// The parsed fields
+	tapeIndex, cellIndex := findAipCell(tapes, o.Instance)
+	e.Prefix = &CellRef{Tape: tapeIndex, Cell: cellIndex}
+	a := result.Aip
+	e.Algorithm, e.AlgorithmSigningComponent, e.Signature, e.Indices = a.Algorithm, a.AlgorithmSigningComponent, a.Signature, a.Indices
+	if algorithm, aErr := normalizeAlgorithm(a.Algorithm, e.Mode); aErr == nil {
+		e.ExpectedSigner = expectedSigner(algorithm, a.AlgorithmSigningComponent)
+	}
+	if len(o.Signer) > 0 {
+		e.ExpectedSigner = o.Signer
+	}
+
+	// The addresses of the recovered keys (on the network of the signing component)
+	network := componentNetwork(a.AlgorithmSigningComponent)
+	for i, pubKey := range observer.pubKeys {
+		if pubKey != nil {
+			e.Attempts[i].CompressedAddress, _ = network.address(pubKey, true)
+			e.Attempts[i].UncompressedAddress, _ = network.address(pubKey, false)
+		}
+	}
+	return e
+}
+
+// expectedSigner returns the address named by the signing component (paymail: of the identity key)
+func expectedSigner(algorithm Algorithm, component string) string {
+	if algorithm != Paymail {
+		return component
+	}
+	pubKey, err := ec.PublicKeyFromString(component)
+	if err != nil {
+		return ""
+	}
+	signer, _ := Mainnet.address(pubKey, true)
+	return signer
+}
+
+// bsmHash returns the hash signed by Bitcoin Signed Message (as bsm.SignMessage builds it)
+//
+// The bsm package of the go-sdk version in go.mod does not export this hash
+func bsmHash(message []byte) []byte {
+	var buf bytes.Buffer
+	buf.Write(transaction.VarInt(len(bsmMagic)).Bytes())
+	buf.WriteString(bsmMagic)
+	buf.Write(transaction.VarInt(len(message)).Bytes())
+	buf.Write(message)
+	return crypto.Sha256d(buf.Bytes())
+}
+
+// String returns the explanation as a readable report
+func (e *Explanation) String() string {
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		_, _ = fmt.Fprintf(&b, format+"\n", args...)
+	}
+
+	line("AIP instance %d (%s mode)", e.Instance, e.Mode)
+	if e.Prefix == nil {
+		line("  prefix:          not found")
+	} else {
+		line("  prefix:          tape %d, cell %d", e.Prefix.Tape, e.Prefix.Cell)
+		line("  algorithm:       %s", e.Algorithm)
+		line("  component:       %s", e.AlgorithmSigningComponent)
+		line("  signature:       %s", e.Signature)
+		if len(e.Indices) > 0 {
+			line("  indices:         %v", e.Indices)
+		}
+		line("  expected signer: %s", e.ExpectedSigner)
+	}
+	for _, attempt := range e.Attempts {
+		line("")
+		line("interpretation %s:", attempt.Interpretation)
+		for i, s := range attempt.Segments {
+			line("  data[%d]: %s %s", i, s.Hex, strconv.Quote(s.Text))
+		}
+		if len(attempt.MessageHex) > 0 {
+			line("  message:              %s", attempt.MessageHex)
+			line("  bsm hash:             %s", attempt.MessageHash)
+		}
+		if len(attempt.RecoveredPubKey) > 0 {
+			line("  recovered pubkey:     %s (compressed: %t)", attempt.RecoveredPubKey, attempt.Compressed)
+			line("  compressed address:   %s", attempt.CompressedAddress)
+			line("  uncompressed address: %s", attempt.UncompressedAddress)
+		}
+		if attempt.FailedStep != "" {
+			line("  failed at %s: %s", attempt.FailedStep, attempt.Error)
+		} else {
+			line("  matched")
+		}
+	}
+	line("")
+	if e.Valid {
+		line("result: valid (%s), signer %s", e.Interpretation, e.Signer)
+	} else if e.FailedStep != "" {
+		line("result: invalid, failed at %s: %s", e.FailedStep, e.Error)
+	} else {
+		line("result: invalid: %s", e.Error)
+	}
+	return b.String()
+}
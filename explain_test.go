@@ -0,0 +1,136 @@
+package aip
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bitcoinschema/go-bpu"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// TestExplain will test the method Explain()
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var bobInvalidData *bob.Tx
+	if bobInvalidData, err = bob.NewFromString(sampleInvalidBobTx); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	trimmed := legacyTapes(t, []string{" hello ", pipe}, "hello"+pipe, BitcoinECDSA)
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name               string
+			inputTapes         []bpu.Tape
+			inputOptions       *ValidateOptions
+			expectedValid      bool
+			expectedFailedStep Stage
+			expectedAttempts   string // Failed step of each attempt ("ok" = matched)
+		}{
+			{"valid", bobValidData.Out[0].Tape, nil, true, "", "ok"},
+			{"invalid address", bobInvalidData.Out[0].Tape, nil, false, StageVerify, "verify"},
+			{"trimmed strict", trimmed, &ValidateOptions{Mode: ModeStrict}, false, StageVerify, "verify"},
+			{"trimmed legacy", trimmed, &ValidateOptions{Mode: ModeLegacy}, true, "", "verify ok"},
+			{"uncompressed", uncompressedTapes(t), nil, false, StageVerify, "verify"},
+			{"unexpected signer", bobValidData.Out[0].Tape, &ValidateOptions{Mode: ModeLegacy, Signer: address(secondPrivateKey)}, false, StageVerify, "verify"},
+			{"unknown algorithm", lowercaseTapes(t), nil, false, StageParse, ""},
+			{"no aip", []bpu.Tape{*new(bpu.Tape)}, nil, false, StageParse, ""},
+			{"unknown mode", bobValidData.Out[0].Tape, &ValidateOptions{Mode: "loose"}, false, "", ""},
+		}
+	)
+
+	for _, test := range tests {
+		e := Explain(test.inputTapes, test.inputOptions)
+		var attempts []string
+		for _, attempt := range e.Attempts {
+			if attempt.FailedStep == "" {
+				attempts = append(attempts, "ok")
+			} else {
+				attempts = append(attempts, string(attempt.FailedStep))
+			}
+		}
+		if e.Valid != test.expectedValid || e.FailedStep != test.expectedFailedStep {
+			t.Errorf("%s Failed: [%s] expected [%t %s] but got [%t %s] (%s)", t.Name(), test.name,
+				test.expectedValid, test.expectedFailedStep, e.Valid, e.FailedStep, e.Error)
+		} else if strings.Join(attempts, " ") != test.expectedAttempts {
+			t.Errorf("%s Failed: [%s] expected attempts [%s] but got [%s]", t.Name(), test.name, test.expectedAttempts, strings.Join(attempts, " "))
+		} else if e.Valid != (e.Error == "") {
+			t.Errorf("%s Failed: [%s] unexpected error [%s]", t.Name(), test.name, e.Error)
+		}
+		if report := e.String(); !strings.Contains(report, "result: ") {
+			t.Errorf("%s Failed: [%s] report has no result: %s", t.Name(), test.name, report)
+		}
+	}
+}
+
+// TestExplain_Details will test the details reported by Explain()
+func TestExplain_Details(t *testing.T) {
+	t.Parallel()
+
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	e := Explain(bobValidData.Out[0].Tape, nil)
+	if e.Prefix == nil || e.Prefix.Tape != 2 || e.Prefix.Cell != 0 {
+		t.Fatalf("%s Failed: expected the prefix at tape 2, cell 0 but got [%v]", t.Name(), e.Prefix)
+	}
+	if e.ExpectedSigner != e.AlgorithmSigningComponent || e.Signer != e.ExpectedSigner {
+		t.Errorf("%s Failed: expected signer [%s] but got [%s]", t.Name(), e.AlgorithmSigningComponent, e.Signer)
+	}
+
+	// The segments make up the message, and the hash recovers the signer key
+	attempt := e.Attempts[0]
+	var joined string
+	for _, s := range attempt.Segments {
+		joined += s.Hex
+	}
+	if joined != attempt.MessageHex || attempt.Segments[0].Text != opReturn {
+		t.Errorf("%s Failed: segments [%s] do not make up the message [%s]", t.Name(), joined, attempt.MessageHex)
+	}
+	a := NewFromTape(bobValidData.Out[0].Tape[e.Prefix.Tape])
+	sig, _ := base64.StdEncoding.DecodeString(a.Signature)
+	hash, _ := hex.DecodeString(attempt.MessageHash)
+	pubKey, _, err := ec.RecoverCompact(sig, hash)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if hex.EncodeToString(pubKey.Compressed()) != attempt.RecoveredPubKey || attempt.CompressedAddress != e.Signer {
+		t.Errorf("%s Failed: expected pubkey [%s] but got [%x]", t.Name(), attempt.RecoveredPubKey, pubKey.Compressed())
+	}
+
+	// Paymail names the identity key, the expected signer is its address
+	s, _ := Sign(examplePrivateKey, Paymail, "hello"+pipe)
+	e = Explain(signedTapes(t, [][]byte{[]byte("hello"), []byte(pipe)}, s), nil)
+	if !e.Valid || e.ExpectedSigner != address(examplePrivateKey) {
+		t.Errorf("%s Failed: expected signer [%s] but got [%s] (%s)", t.Name(), address(examplePrivateKey), e.ExpectedSigner, e.Error)
+	}
+}
+
+// ExampleExplain example using Explain()
+func ExampleExplain() {
+	bobInvalidData, err := bob.NewFromString(sampleInvalidBobTx)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	e := Explain(bobInvalidData.Out[0].Tape, nil)
+	fmt.Printf("prefix: tape %d, cell %d failed at: %s", e.Prefix.Tape, e.Prefix.Cell, e.FailedStep)
+	// Output:prefix: tape 2, cell 0 failed at: verify
+}
+
+// BenchmarkExplain benchmarks the method Explain()
+func BenchmarkExplain(b *testing.B) {
+	bobValidData, _ := bob.NewFromString(sampleValidBobTx)
+	for i := 0; i < b.N; i++ {
+		_ = Explain(bobValidData.Out[0].Tape, nil)
+	}
+}
@@ -0,0 +1,259 @@
+package aip
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// HTTP headers carrying the request signature
+const (
+	HeaderAlgorithm = "X-Aip-Algorithm" // The AIP algorithm
+	HeaderAddress   = "X-Aip-Address"   // The signing address (identity key for paymail)
+	HeaderSignature = "X-Aip-Signature" // The signature (base64)
+	HeaderTimestamp = "X-Aip-Timestamp" // Unix time (seconds) when the request was signed
+	HeaderNonce     = "X-Aip-Nonce"     // Random value that is only accepted once per signer
+)
+
+// DefaultMaxSkew is the clock-skew window used when none is set
+const DefaultMaxSkew = 5 * time.Minute
+
+// DefaultMaxBodyBytes is the largest request body verified when no limit is set (10 MiB)
+const DefaultMaxBodyBytes int64 = 10 << 20
+
+// signerContextKey is the request context key of the verified signer
+type signerContextKey struct{}
+
+// RequestMessage returns the message signed for an HTTP request
+//
+// It is the method, the host, the request URI (path and query), the timestamp,
+// the nonce and the hex SHA-256 of the body, one per line.
+func RequestMessage(method, host, requestURI string, timestamp int64, nonce string, body []byte) string {
+	hash := sha256.Sum256(body)
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		strings.ToLower(host),
+		requestURI,
+		strconv.FormatInt(timestamp, 10),
+		nonce,
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+}
+
+// SigningTransport is an http.RoundTripper that signs every request with AIP
+type SigningTransport struct {
+	PrivateKey *ec.PrivateKey    // The signing key (required)
+	Algorithm  Algorithm         // Defaults to BitcoinSignedMessage
+	Base       http.RoundTripper // Defaults to http.DefaultTransport
+	Now        func() time.Time  // Clock (defaults to time.Now)
+}
+
+// RoundTrip implements http.RoundTripper, the request is cloned before the headers are set
+func (t *SigningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req, 0)
+	if err != nil {
+		return nil, err
+	}
+	if t.PrivateKey == nil {
+		return nil, errors.New("private key is required")
+	}
+	algorithm := t.Algorithm
+	if len(algorithm) == 0 {
+		algorithm = BitcoinSignedMessage
+	}
+	now := time.Now
+	if t.Now != nil {
+		now = t.Now
+	}
+
+	var nonce string
+	if nonce, err = NewNonce(); err != nil {
+		return nil, err
+	}
+	timestamp := now().Unix()
+	host := req.Host
+	if len(host) == 0 {
+		host = req.URL.Host
+	}
+	var a *Aip
+	if a, err = Sign(t.PrivateKey, algorithm,
+		RequestMessage(req.Method, host, req.URL.RequestURI(), timestamp, nonce, body)); err != nil {
+		return nil, err
+	}
+
+	signed := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		signed.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	signed.Header.Set(HeaderAlgorithm, string(a.Algorithm))
+	signed.Header.Set(HeaderAddress, a.AlgorithmSigningComponent)
+	signed.Header.Set(HeaderSignature, a.Signature)
+	signed.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	signed.Header.Set(HeaderNonce, nonce)
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(signed)
+}
+
+// HTTPAuthOptions are the options used by VerifyRequest and HTTPAuthMiddleware
+type HTTPAuthOptions struct {
+	MaxBodyBytes int64            // Largest accepted body (defaults to DefaultMaxBodyBytes)
+	MaxSkew      time.Duration    // Accepted clock difference either way (defaults to DefaultMaxSkew)
+	Now          func() time.Time // Clock (defaults to time.Now)
+	Store        NonceStore       // Seen nonces (the middleware defaults to a MemoryNonceStore)
+}
+
+// maxBodyBytes returns the body limit (DefaultMaxBodyBytes if none is set)
+func (o *HTTPAuthOptions) maxBodyBytes() int64 {
+	if o.MaxBodyBytes <= 0 {
+		return DefaultMaxBodyBytes
+	}
+	return o.MaxBodyBytes
+}
+
+// VerifyRequest verifies the AIP signature headers of the request and returns the result
+//
+// The body is read (up to MaxBodyBytes) and replaced, so the request can still be
+// handled. The request is verified for the Host it was sent to. The nonce is only
+// checked when a store is set.
+func VerifyRequest(r *http.Request, opts *HTTPAuthOptions) (*ValidationResult, error) {
+	if opts == nil {
+		opts = &HTTPAuthOptions{}
+	}
+	maxSkew := opts.MaxSkew
+	if maxSkew <= 0 {
+		maxSkew = DefaultMaxSkew
+	}
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+
+	a := &Aip{
+		Algorithm:                 Algorithm(r.Header.Get(HeaderAlgorithm)),
+		AlgorithmSigningComponent: r.Header.Get(HeaderAddress),
+		Signature:                 r.Header.Get(HeaderSignature),
+	}
+	result := &ValidationResult{Aip: a, Mode: ModeStrict}
+	nonce := r.Header.Get(HeaderNonce)
+	if len(a.Algorithm) == 0 || len(a.AlgorithmSigningComponent) == 0 || len(a.Signature) == 0 || len(nonce) == 0 {
+		return result, errors.New("missing signature headers")
+	}
+	algorithm, err := normalizeAlgorithm(a.Algorithm, ModeStrict)
+	if err != nil {
+		return result, err
+	}
+
+	// Freshness
+	var timestamp int64
+	if timestamp, err = strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64); err != nil {
+		return result, fmt.Errorf("invalid %s header: %s", HeaderTimestamp, r.Header.Get(HeaderTimestamp))
+	}
+	signedAt := time.Unix(timestamp, 0)
+	if skew := now().Sub(signedAt); skew > maxSkew || skew < -maxSkew {
+		return result, fmt.Errorf("signed timestamp is outside the allowed clock skew: %s", signedAt.UTC().Format(time.RFC3339))
+	}
+
+	// Signature
+	var body []byte
+	if body, err = readBody(r, opts.maxBodyBytes()); err != nil {
+		return result, err
+	}
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	a.Data = []string{opReturn, RequestMessage(r.Method, r.Host, r.URL.RequestURI(), timestamp, nonce, body)}
+	var sig []byte
+	if sig, err = base64.StdEncoding.DecodeString(a.Signature); err != nil {
+		return result, err
+	}
+	if result.Signer, result.Compressed, err = verifySigner(
+		algorithm, a.AlgorithmSigningComponent, sig, []byte(strings.Join(a.Data, "")),
+	); err != nil {
+		return result, err
+	}
+
+	// Uniqueness (the nonce is kept until the timestamp leaves the window)
+	if opts.Store != nil {
+		var seen bool
+		if seen, err = opts.Store.Seen(result.Signer, nonce, signedAt.Add(maxSkew)); err != nil {
+			return result, err
+		} else if seen {
+			return result, fmt.Errorf("nonce already used: %s", nonce)
+		}
+	}
+	result.Valid = true
+	return result, nil
+}
+
+// HTTPAuthMiddleware only passes requests with a valid AIP signature to next
+//
+// Other requests get 401 Unauthorized, or 413 Request Entity Too Large when the
+// body is over MaxBodyBytes. The verified signer address is available to next
+// with SignerFromContext.
+func HTTPAuthMiddleware(next http.Handler, opts *HTTPAuthOptions) http.Handler {
+	o := HTTPAuthOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Store == nil {
+		o.Store = NewMemoryNonceStore()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = http.MaxBytesReader(w, r.Body, o.maxBodyBytes())
+		}
+		result, err := VerifyRequest(r, &o)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		} else if err != nil {
+			http.Error(w, "invalid AIP signature: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), signerContextKey{}, result.Signer)))
+	})
+}
+
+// SignerFromContext returns the signer address verified by HTTPAuthMiddleware
+func SignerFromContext(ctx context.Context) (string, bool) {
+	signer, ok := ctx.Value(signerContextKey{}).(string)
+	return signer, ok
+}
+
+// readBody reads and closes the request body (an empty body if there is none)
+//
+// Bodies over the limit (0 = no limit) are an error.
+func readBody(r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	defer func() {
+		_ = r.Body.Close()
+	}()
+	if limit <= 0 {
+		return io.ReadAll(r.Body)
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	if err != nil {
+		return nil, err
+	} else if int64(len(body)) > limit {
+		return nil, &http.MaxBytesError{Limit: limit}
+	}
+	return body, nil
+}
@@ -0,0 +1,195 @@
+package aip
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signerHandler responds with the signer and the body it received
+var signerHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	signer, _ := SignerFromContext(r.Context())
+	body, _ := io.ReadAll(r.Body)
+	_, _ = fmt.Fprintf(w, "%s %s", signer, body)
+})
+
+// roundTripFunc is an http.RoundTripper made from a function
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// signedRequest returns a request signed by the transport (and not sent)
+func signedRequest(t testing.TB, transport *SigningTransport, method, url, body string) *http.Request {
+	var signed *http.Request
+	transport.Base = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		signed = req
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	req.RequestURI = ""
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return signed
+}
+
+// TestHTTPAuthMiddleware will test the methods SigningTransport.RoundTrip() and HTTPAuthMiddleware()
+func TestHTTPAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(HTTPAuthMiddleware(signerHandler, nil))
+	defer server.Close()
+
+	for _, algorithm := range []Algorithm{"", BitcoinECDSA, BitcoinSignedMessage, Paymail} {
+		client := &http.Client{Transport: &SigningTransport{PrivateKey: examplePrivateKey, Algorithm: algorithm}}
+		resp, err := client.Post(server.URL+"/items?id=1", "text/plain", strings.NewReader("hello"))
+		if err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), algorithm, err.Error())
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(body) != exampleAddress+" hello" {
+			t.Errorf("%s Failed: [%s] expected [%s hello] but got [%d %s]", t.Name(), algorithm, exampleAddress, resp.StatusCode, body)
+		}
+	}
+
+	// Unsigned requests are refused
+	resp, err := http.Get(server.URL + "/items")
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("%s Failed: expected status [%d] but got [%d]", t.Name(), http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	// Bodies over the limit are refused before they are verified
+	limited := httptest.NewServer(HTTPAuthMiddleware(signerHandler, &HTTPAuthOptions{MaxBodyBytes: 4}))
+	defer limited.Close()
+	signing := &http.Client{Transport: &SigningTransport{PrivateKey: examplePrivateKey}}
+	if resp, err = signing.Post(limited.URL, "text/plain", strings.NewReader("hello")); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("%s Failed: expected status [%d] but got [%d]", t.Name(), http.StatusRequestEntityTooLarge, resp.StatusCode)
+	}
+
+	// Missing key
+	client := &http.Client{Transport: &SigningTransport{}}
+	if _, err = client.Get(server.URL); err == nil {
+		t.Errorf("%s Failed: error was expected (missing key)", t.Name())
+	}
+}
+
+// TestVerifyRequest will test the method VerifyRequest()
+func TestVerifyRequest(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+	transport := &SigningTransport{PrivateKey: examplePrivateKey, Now: clock}
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name          string
+			modify        func(req *http.Request)
+			opts          *HTTPAuthOptions
+			expectedError bool
+		}{
+			{"valid", func(*http.Request) {}, &HTTPAuthOptions{Now: clock}, false},
+			{"within skew", func(*http.Request) {}, &HTTPAuthOptions{Now: func() time.Time { return now.Add(-DefaultMaxSkew) }}, false},
+			{"too old", func(*http.Request) {}, &HTTPAuthOptions{Now: func() time.Time { return now.Add(time.Hour) }}, true},
+			{"in the future", func(*http.Request) {}, &HTTPAuthOptions{Now: func() time.Time { return now.Add(-time.Minute) }, MaxSkew: time.Second}, true},
+			{"other method", func(req *http.Request) { req.Method = http.MethodPut }, &HTTPAuthOptions{Now: clock}, true},
+			{"other host", func(req *http.Request) { req.Host = "other.example.com" }, &HTTPAuthOptions{Now: clock}, true},
+			{"host case", func(req *http.Request) { req.Host = "EXAMPLE.com" }, &HTTPAuthOptions{Now: clock}, false},
+			{"other path", func(req *http.Request) { req.URL.Path = "/other" }, &HTTPAuthOptions{Now: clock}, true},
+			{"other query", func(req *http.Request) { req.URL.RawQuery = "id=2" }, &HTTPAuthOptions{Now: clock}, true},
+			{"other body", func(req *http.Request) { req.Body = io.NopCloser(strings.NewReader("hellO")) }, &HTTPAuthOptions{Now: clock}, true},
+			{"other timestamp", func(req *http.Request) { req.Header.Set(HeaderTimestamp, "1700000001") }, &HTTPAuthOptions{Now: clock}, true},
+			{"other nonce", func(req *http.Request) { req.Header.Set(HeaderNonce, "abc") }, &HTTPAuthOptions{Now: clock}, true},
+			{"other address", func(req *http.Request) { req.Header.Set(HeaderAddress, address(secondPrivateKey)) }, &HTTPAuthOptions{Now: clock}, true},
+			{"invalid timestamp", func(req *http.Request) { req.Header.Set(HeaderTimestamp, "now") }, &HTTPAuthOptions{Now: clock}, true},
+			{"invalid signature", func(req *http.Request) { req.Header.Set(HeaderSignature, "invalid-sig") }, &HTTPAuthOptions{Now: clock}, true},
+			{"unknown algorithm", func(req *http.Request) { req.Header.Set(HeaderAlgorithm, "unknown") }, &HTTPAuthOptions{Now: clock}, true},
+			{"missing nonce", func(req *http.Request) { req.Header.Del(HeaderNonce) }, &HTTPAuthOptions{Now: clock}, true},
+			{"missing signature", func(req *http.Request) { req.Header.Del(HeaderSignature) }, nil, true},
+			{"body at the limit", func(*http.Request) {}, &HTTPAuthOptions{Now: clock, MaxBodyBytes: 5}, false},
+			{"body too large", func(*http.Request) {}, &HTTPAuthOptions{Now: clock, MaxBodyBytes: 4}, true},
+		}
+	)
+
+	for _, test := range tests {
+		req := signedRequest(t, transport, http.MethodPost, "http://example.com/items?id=1", "hello")
+		test.modify(req)
+		result, err := VerifyRequest(req, test.opts)
+		if err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] error was expected", t.Name(), test.name)
+		} else if err == nil && (!result.Valid || result.Signer != exampleAddress) {
+			t.Errorf("%s Failed: [%s] expected signer [%s] but got [%s]", t.Name(), test.name, exampleAddress, result.Signer)
+		} else if err == nil {
+			if body, _ := io.ReadAll(req.Body); string(body) != "hello" {
+				t.Errorf("%s Failed: [%s] expected the body to be kept but got [%s]", t.Name(), test.name, body)
+			}
+		}
+	}
+}
+
+// TestVerifyRequest_Nonce will test the method VerifyRequest() with a nonce store
+func TestVerifyRequest_Nonce(t *testing.T) {
+	t.Parallel()
+
+	opts := &HTTPAuthOptions{Store: NewMemoryNonceStore()}
+	req := signedRequest(t, &SigningTransport{PrivateKey: examplePrivateKey}, http.MethodGet, "http://example.com/", "")
+	if _, err := VerifyRequest(req, opts); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	if _, err := VerifyRequest(req, opts); err == nil {
+		t.Errorf("%s Failed: error was expected (replayed nonce)", t.Name())
+	}
+
+	// Every signed request has a new nonce
+	other := signedRequest(t, &SigningTransport{PrivateKey: examplePrivateKey}, http.MethodGet, "http://example.com/", "")
+	if _, err := VerifyRequest(other, opts); err != nil {
+		t.Errorf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+}
+
+// TestSignerFromContext will test the method SignerFromContext()
+func TestSignerFromContext(t *testing.T) {
+	t.Parallel()
+
+	if signer, ok := SignerFromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()); ok || signer != "" {
+		t.Errorf("%s Failed: expected no signer but got [%s]", t.Name(), signer)
+	}
+}
+
+// ExampleRequestMessage example using RequestMessage()
+func ExampleRequestMessage() {
+	fmt.Println(RequestMessage("post", "Example.com", "/items?id=1", 1700000000, "abc", []byte("hello")))
+	// Output:POST
+	// example.com
+	// /items?id=1
+	// 1700000000
+	// abc
+	// 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824
+}
+
+// BenchmarkVerifyRequest benchmarks the method VerifyRequest()
+func BenchmarkVerifyRequest(b *testing.B) {
+	req := signedRequest(b, &SigningTransport{PrivateKey: examplePrivateKey}, http.MethodGet, "http://example.com/", "")
+	for i := 0; i < b.N; i++ {
+		_, _ = VerifyRequest(req, nil)
+	}
+}
@@ -0,0 +1,121 @@
+package aip
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/bitcoinschema/go-bpu"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// inscriptionMarker is the first push of an ordinal inscription envelope
+const inscriptionMarker = "ord"
+
+// Inscription is a 1Sat ordinal inscription (OP_FALSE OP_IF "ord" ... OP_ENDIF)
+type Inscription struct {
+	ContentType string `json:"content_type"` // Field 1 (OP_1)
+	Content     []byte `json:"content"`      // Field 0 (OP_0)
+}
+
+// Script returns the inscription envelope as a script
+func (i *Inscription) Script() *script.Script {
+	s := &script.Script{}
+	_ = s.AppendOpcodes(script.OpFALSE, script.OpIF)
+	_ = s.AppendPushData([]byte(inscriptionMarker))
+	_ = s.AppendOpcodes(script.Op1)
+	_ = s.AppendPushData([]byte(i.ContentType))
+	_ = s.AppendOpcodes(script.Op0)
+	_ = s.AppendPushData(i.Content)
+	_ = s.AppendOpcodes(script.OpENDIF)
+	return s
+}
+
+// SignInscription appends an inscription, the data and an AIP signature to a locking script
+//
+// The output is: <locking script> OP_FALSE OP_IF "ord" OP_1 <type> OP_0 <content> OP_ENDIF | <data> | <AIP>
+// and the signed payload follows the EnvelopeInscription rules (OP_RETURN + "ord" + type + content + | + data + |)
+func SignInscription(privateKey *ec.PrivateKey, algorithm Algorithm, lockingScript *script.Script,
+	inscription *Inscription, data [][]byte) (*script.Script, *Aip, error) {
+
+	if lockingScript == nil || len(*lockingScript) == 0 {
+		return nil, nil, errors.New("locking script is required")
+	} else if inscription == nil {
+		return nil, nil, errors.New("inscription is required")
+	}
+
+	// Pushes after OP_ENDIF: a pipe, the data and a pipe (if there is data)
+	signed := []string{inscriptionMarker, inscription.ContentType, string(inscription.Content), pipe}
+	pushes := [][]byte{[]byte(pipe)}
+	if len(data) > 0 {
+		for _, d := range data {
+			signed = append(signed, string(d))
+		}
+		signed = append(signed, pipe)
+		pushes = append(append(pushes, data...), []byte(pipe))
+	}
+
+	// Sign with AIP
+	a, err := Sign(privateKey, algorithm, strings.Join(signed, ""))
+	if err != nil {
+		return nil, nil, err
+	}
+	var aipPushes [][]byte
+	if aipPushes, err = a.MarshalPushes(); err != nil {
+		return nil, nil, err
+	}
+
+	s := &script.Script{}
+	*s = append(*s, *lockingScript...)
+	*s = append(*s, *inscription.Script()...)
+	if err = s.AppendPushDataArray(append(pushes, aipPushes...)); err != nil {
+		return nil, nil, err
+	}
+	return s, a, nil
+}
+
+// NewInscriptionFromTapes returns the first inscription found in the output tapes
+func NewInscriptionFromTapes(tapes []bpu.Tape) (*Inscription, error) {
+	var cells []bpu.Cell
+	for _, tape := range tapes {
+		cells = append(cells, tape.Cell...)
+	}
+
+	// Find OP_FALSE OP_IF "ord"
+	start := -1
+	for i := 2; i < len(cells); i++ {
+		if isOp(cells[i-2], script.OpFALSE) && isOp(cells[i-1], script.OpIF) &&
+			cells[i].S != nil && *cells[i].S == inscriptionMarker {
+			start = i + 1
+			break
+		}
+	}
+	if start < 0 {
+		return nil, errors.New("no inscription found")
+	}
+
+	// Read the field tag and value pairs until OP_ENDIF
+	inscription := new(Inscription)
+	for i := start; i < len(cells); i += 2 {
+		if isOp(cells[i], script.OpENDIF) {
+			return inscription, nil
+		} else if i+1 >= len(cells) || cells[i].Op == nil {
+			break
+		}
+		var value []byte
+		if cells[i+1].H != nil {
+			var err error
+			if value, err = hex.DecodeString(*cells[i+1].H); err != nil {
+				return nil, err
+			}
+		}
+		switch *cells[i].Op {
+		case script.Op1:
+			inscription.ContentType = string(value)
+		case script.Op0:
+			inscription.Content = value
+		}
+	}
+	return nil, errors.New("inscription is not terminated")
+}
@@ -0,0 +1,126 @@
+package aip
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+)
+
+// TestSignInscription will test the method SignInscription()
+func TestSignInscription(t *testing.T) {
+	t.Parallel()
+
+	addr, _ := script.NewAddressFromPublicKey(examplePrivateKey.PubKey(), true)
+	lock, _ := p2pkh.Lock(addr)
+	mapData := [][]byte{[]byte("1PuQa7K62MiKCtssSLKy1kh56WWU7MtUR5"), []byte("SET"), []byte("app"), []byte("go-aip")}
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name              string
+			inputLock         *script.Script
+			inputInscription  *Inscription
+			inputData         [][]byte
+			inputAlgorithm    Algorithm
+			expectedComponent string
+			expectedError     bool
+		}{
+			{"with data", lock, &Inscription{ContentType: "text/plain", Content: []byte("hello world")}, mapData, BitcoinECDSA, addr.AddressString, false},
+			{"without data", lock, &Inscription{ContentType: "text/plain", Content: []byte("hello world")}, nil, BitcoinSignedMessage, addr.AddressString, false},
+			{"binary content", lock, &Inscription{ContentType: "image/png", Content: []byte{0x89, 0x50, 0x4e, 0x47, 0x00, 0x0a}}, mapData, Paymail, hex.EncodeToString(examplePrivateKey.PubKey().Compressed()), false},
+			{"missing locking script", nil, &Inscription{ContentType: "text/plain"}, mapData, BitcoinECDSA, "", true},
+			{"missing inscription", lock, nil, mapData, BitcoinECDSA, "", true},
+		}
+	)
+
+	for _, test := range tests {
+		s, a, err := SignInscription(examplePrivateKey, test.inputAlgorithm, test.inputLock, test.inputInscription, test.inputData)
+		if err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+			continue
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] error was expected", t.Name(), test.name)
+			continue
+		} else if err != nil {
+			continue
+		} else if a.AlgorithmSigningComponent != test.expectedComponent {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%s]", t.Name(), test.name, test.expectedComponent, a.AlgorithmSigningComponent)
+		}
+
+		var envelope Envelope
+		if envelope, err = DetectScriptEnvelope(s); err != nil || envelope != EnvelopeInscription {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%s] [%v]", t.Name(), test.name, EnvelopeInscription, envelope, err)
+		}
+
+		tapes := parseScript(t, s)
+		var result *ValidationResult
+		if result, err = ValidateTapesWithOptions(tapes, nil); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if !result.Valid || result.Envelope != EnvelopeInscription {
+			t.Errorf("%s Failed: [%s] expected a valid inscription but got [%t] [%s]", t.Name(), test.name, result.Valid, result.Envelope)
+		}
+
+		var inscription *Inscription
+		if inscription, err = NewInscriptionFromTapes(tapes); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if inscription.ContentType != test.inputInscription.ContentType ||
+			hex.EncodeToString(inscription.Content) != hex.EncodeToString(test.inputInscription.Content) {
+			t.Errorf("%s Failed: [%s] expected [%v] but got [%v]", t.Name(), test.name, test.inputInscription, inscription)
+		}
+	}
+}
+
+// TestNewInscriptionFromTapes will test the method NewInscriptionFromTapes() with invalid tapes
+func TestNewInscriptionFromTapes(t *testing.T) {
+	t.Parallel()
+
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	if _, err = NewInscriptionFromTapes(bobValidData.Out[0].Tape); err == nil {
+		t.Errorf("%s Failed: error was expected (no inscription)", t.Name())
+	}
+
+	// Envelope without OP_ENDIF
+	s := &script.Script{}
+	_ = s.AppendOpcodes(script.OpFALSE, script.OpIF)
+	_ = s.AppendPushData([]byte(inscriptionMarker))
+	_ = s.AppendOpcodes(script.Op1)
+	_ = s.AppendPushData([]byte("text/plain"))
+	if _, err = NewInscriptionFromTapes(parseScript(t, s)); err == nil {
+		t.Errorf("%s Failed: error was expected (not terminated)", t.Name())
+	}
+}
+
+// ExampleSignInscription example using SignInscription()
+func ExampleSignInscription() {
+	addr, _ := script.NewAddressFromPublicKey(examplePrivateKey.PubKey(), true)
+	lock, _ := p2pkh.Lock(addr)
+	_, a, err := SignInscription(
+		examplePrivateKey, BitcoinSignedMessage, lock,
+		&Inscription{ContentType: "text/plain", Content: []byte("hello world")},
+		[][]byte{[]byte("1PuQa7K62MiKCtssSLKy1kh56WWU7MtUR5"), []byte("SET"), []byte("app"), []byte("go-aip")},
+	)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("address: %s data: %q", a.AlgorithmSigningComponent, a.Data[1])
+	// Output:address: 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK data: "ordtext/plainhello world|1PuQa7K62MiKCtssSLKy1kh56WWU7MtUR5SETappgo-aip|"
+}
+
+// BenchmarkSignInscription benchmarks the method SignInscription()
+func BenchmarkSignInscription(b *testing.B) {
+	addr, _ := script.NewAddressFromPublicKey(examplePrivateKey.PubKey(), true)
+	lock, _ := p2pkh.Lock(addr)
+	inscription := &Inscription{ContentType: "text/plain", Content: []byte("hello world")}
+	data := [][]byte{[]byte("1PuQa7K62MiKCtssSLKy1kh56WWU7MtUR5"), []byte("SET"), []byte("app"), []byte("go-aip")}
+	for i := 0; i < b.N; i++ {
+		_, _, _ = SignInscription(examplePrivateKey, BitcoinECDSA, lock, inscription, data)
+	}
+}
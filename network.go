@@ -0,0 +1,147 @@
+package aip
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	bsm "github.com/bsv-blockchain/go-sdk/compat/bsm"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// Network is the Bitcoin network an address is encoded for
+type Network string
+
+// Known networks (regtest shares the testnet address prefix)
+const (
+	Mainnet Network = "mainnet"
+	Testnet Network = "testnet"
+	Regtest Network = "regtest"
+)
+
+// mainnet returns true if the network uses mainnet addresses (empty = mainnet)
+func (n Network) mainnet() (bool, error) {
+	switch n {
+	case "", Mainnet:
+		return true, nil
+	case Testnet, Regtest:
+		return false, nil
+	}
+	return false, fmt.Errorf("unknown network: %s", n)
+}
+
+// address returns the P2PKH address of the key on the network
+func (n Network) address(pubKey *ec.PublicKey, compressed bool) (string, error) {
+	mainnet, err := n.mainnet()
+	if err != nil {
+		return "", err
+	}
+	var addr *script.Address
+	if addr, err = script.NewAddressFromPublicKeyWithCompression(pubKey, mainnet, compressed); err != nil {
+		return "", err
+	}
+	return addr.AddressString, nil
+}
+
+// AddressNetwork returns the network of a P2PKH address
+//
+// Regtest and testnet addresses cannot be told apart, both are reported as Testnet.
+func AddressNetwork(address string) (Network, error) {
+	_, network, err := parseAddress(address)
+	return network, err
+}
+
+// parseAddress decodes a P2PKH address (checking the checksum) into its hash160 and network
+func parseAddress(address string) ([]byte, Network, error) {
+	addr, err := script.NewAddressFromString(address)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, network := range []Network{Mainnet, Testnet} {
+		mainnet, _ := network.mainnet()
+		var encoded *script.Address
+		if encoded, err = script.NewAddressFromPublicKeyHash(addr.PublicKeyHash, mainnet); err != nil {
+			return nil, "", err
+		} else if encoded.AddressString == address {
+			return addr.PublicKeyHash, network, nil
+		}
+	}
+	return nil, "", fmt.Errorf("invalid address checksum: %s", address)
+}
+
+// componentNetwork returns the network of the signing component (mainnet unless it is a testnet address)
+func componentNetwork(component string) Network {
+	if _, network, err := parseAddress(component); err == nil {
+		return network
+	}
+	return Mainnet
+}
+
+// pubKeyHash returns the hash160 of the key as it was serialized when signing
+func pubKeyHash(pubKey *ec.PublicKey, compressed bool) []byte {
+	if compressed {
+		return pubKey.Hash()
+	}
+	return crypto.Hash160(pubKey.Uncompressed())
+}
+
+// matchSigner checks the key identifies the expected signer, given as an address
+// (any network), a hex public key or a hex hash160
+func matchSigner(expected string, pubKey *ec.PublicKey, compressed bool) error {
+	hash := pubKeyHash(pubKey, compressed)
+	switch len(expected) {
+	case 40:
+		if expectedHash, err := hex.DecodeString(expected); err == nil {
+			if !bytes.Equal(expectedHash, hash) {
+				return fmt.Errorf("hash160 (%s) does not match the signer (%x)", expected, hash)
+			}
+			return nil
+		}
+	case 66, 130:
+		if expectedKey, err := ec.PublicKeyFromString(expected); err == nil {
+			if !expectedKey.IsEqual(pubKey) {
+				return fmt.Errorf("public key (%s) does not match the signer", expected)
+			}
+			return nil
+		}
+	}
+	expectedHash, _, err := parseAddress(expected)
+	if err != nil {
+		return fmt.Errorf("expected signer is not an address, public key or hash160: %s", expected)
+	}
+	if !bytes.Equal(expectedHash, hash) {
+		return fmt.Errorf("address (%s) does not match the signer (%x)", expected, hash)
+	}
+	return nil
+}
+
+// checkSigner recovers the key that signed the message and checks it is the expected signer
+func checkSigner(expected string, sig, message []byte) error {
+	pubKey, wasCompressed, err := bsm.PubKeyFromSignature(sig, message)
+	if err != nil {
+		return err
+	}
+	return matchSigner(expected, pubKey, wasCompressed)
+}
+
+// ValidateSigner returns true if the AIP signature is valid and was made by the
+// expected signer: an address (any network), a hex public key or a hex hash160
+func (a *Aip) ValidateSigner(expected string) (bool, error) {
+	if len(expected) == 0 {
+		return false, errors.New("expected signer is required")
+	}
+	if valid, err := a.Validate(); !valid {
+		return false, err
+	}
+	sig, err := base64.StdEncoding.DecodeString(a.Signature)
+	if err != nil {
+		return false, err
+	}
+	err = checkSigner(expected, sig, []byte(strings.Join(a.Data, "")))
+	return err == nil, err
+}
@@ -0,0 +1,204 @@
+package aip
+
+import (
+	"fmt"
+	"testing"
+)
+
+// The example key on each network and its other identities
+const (
+	exampleAddress        = "1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK"
+	exampleTestnetAddress = "mtBEFNrf94fiib6zW6JZjZTFEpLK7RqN3i"
+	examplePubKey         = "031b8c93100d35bd448f4646cc4678f278351b439b52b303ea31ec9edb5475e73f"
+	exampleHash160        = "8adea231133a12a381578166d37f4049c9710e43"
+)
+
+// TestSignWithNetwork will test the method SignWithNetwork()
+func TestSignWithNetwork(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			network         Network
+			expectedAddress string
+			expectedError   bool
+		}{
+			{"", exampleAddress, false},
+			{Mainnet, exampleAddress, false},
+			{Testnet, exampleTestnetAddress, false},
+			{Regtest, exampleTestnetAddress, false},
+			{"signet", "", true},
+		}
+	)
+
+	for _, test := range tests {
+		a, err := SignWithNetwork(examplePrivateKey, BitcoinSignedMessage, exampleMessage, test.network)
+		if err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] inputted and error not expected but got: %s", t.Name(), test.network, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] inputted and error was expected", t.Name(), test.network)
+		} else if err == nil && a.AlgorithmSigningComponent != test.expectedAddress {
+			t.Errorf("%s Failed: [%s] inputted and expected [%s] but got [%s]", t.Name(), test.network, test.expectedAddress, a.AlgorithmSigningComponent)
+		} else if err == nil {
+			if valid, err := a.Validate(); !valid {
+				t.Errorf("%s Failed: [%s] inputted and signature should be valid, error: %v", t.Name(), test.network, err)
+			}
+		}
+	}
+
+	// Paymail signatures carry the identity key on every network
+	if a, err := SignWithNetwork(examplePrivateKey, Paymail, exampleMessage, Testnet); err != nil {
+		t.Errorf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if a.AlgorithmSigningComponent != examplePubKey {
+		t.Errorf("%s Failed: expected [%s] but got [%s]", t.Name(), examplePubKey, a.AlgorithmSigningComponent)
+	}
+}
+
+// TestSignOpReturnDataWithOptions_Network will test the method SignOpReturnDataWithOptions() with a network
+func TestSignOpReturnDataWithOptions_Network(t *testing.T) {
+	t.Parallel()
+
+	data := [][]byte{[]byte("hello"), []byte(pipe)}
+	outData, a, err := SignOpReturnDataWithOptions(examplePrivateKey, BitcoinSignedMessage, data, &SignOptions{Network: Testnet})
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if string(outData[4]) != exampleTestnetAddress {
+		t.Errorf("%s Failed: expected [%s] but got [%s]", t.Name(), exampleTestnetAddress, outData[4])
+	}
+	tapes := signedTapes(t, data, a)
+	result, err := ValidateTapesWithOptions(tapes, nil)
+	if err != nil || !result.Valid {
+		t.Fatalf("%s Failed: testnet signature should be valid, error: %v", t.Name(), err)
+	} else if result.Signer != exampleTestnetAddress {
+		t.Errorf("%s Failed: expected signer [%s] but got [%s]", t.Name(), exampleTestnetAddress, result.Signer)
+	}
+
+	// Records and explanations report the signer on the network of the signing component
+	a.Data = result.Aip.Data
+	if r := NewRecord(a, "", 0, 0); !r.Valid || r.Signer != exampleTestnetAddress {
+		t.Errorf("%s Failed: expected record signer [%s] but got [%t] [%s]", t.Name(), exampleTestnetAddress, r.Valid, r.Signer)
+	}
+	if e := Explain(tapes, nil); !e.Valid || len(e.Attempts) != 1 || e.Attempts[0].CompressedAddress != exampleTestnetAddress {
+		t.Errorf("%s Failed: expected explained address [%s] but got [%v]", t.Name(), exampleTestnetAddress, e)
+	}
+
+	if _, _, err = SignOpReturnDataWithOptions(examplePrivateKey, BitcoinSignedMessage, data, &SignOptions{Network: "signet"}); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown network)", t.Name())
+	}
+}
+
+// TestAddressNetwork will test the method AddressNetwork()
+func TestAddressNetwork(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			address         string
+			expectedNetwork Network
+			expectedError   bool
+		}{
+			{exampleAddress, Mainnet, false},
+			{exampleTestnetAddress, Testnet, false},
+			{"1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgL", "", true},
+			{"invalid-address", "", true},
+			{"", "", true},
+		}
+	)
+
+	for _, test := range tests {
+		if network, err := AddressNetwork(test.address); err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] inputted and error not expected but got: %s", t.Name(), test.address, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] inputted and error was expected", t.Name(), test.address)
+		} else if network != test.expectedNetwork {
+			t.Errorf("%s Failed: [%s] inputted and expected [%s] but got [%s]", t.Name(), test.address, test.expectedNetwork, network)
+		}
+	}
+}
+
+// TestAip_ValidateSigner will test the method ValidateSigner()
+func TestAip_ValidateSigner(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			signer        string
+			expectedValid bool
+		}{
+			{exampleAddress, true},
+			{exampleTestnetAddress, true},
+			{examplePubKey, true},
+			{exampleHash160, true},
+			{"8ADEA231133A12A381578166D37F4049C9710E43", true},
+			{address(secondPrivateKey), false},
+			{"0000000000000000000000000000000000000000", false},
+			{"02" + exampleHash160 + exampleHash160[:24], false},
+			{"not-a-signer", false},
+			{"", false},
+		}
+	)
+
+	for _, network := range []Network{Mainnet, Testnet} {
+		a, err := SignWithNetwork(examplePrivateKey, BitcoinSignedMessage, exampleMessage, network)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		for _, test := range tests {
+			if valid, err := a.ValidateSigner(test.signer); valid != test.expectedValid {
+				t.Errorf("%s Failed: [%s] [%s] inputted and expected [%t] but got [%t], error: %v", t.Name(), network, test.signer, test.expectedValid, valid, err)
+			}
+		}
+	}
+
+	// An invalid signature never matches
+	a, _ := Sign(examplePrivateKey, BitcoinSignedMessage, exampleMessage)
+	a.Data = []string{opReturn, "tampered"}
+	if valid, _ := a.ValidateSigner(exampleAddress); valid {
+		t.Errorf("%s Failed: tampered data should not be valid", t.Name())
+	}
+}
+
+// TestValidateTapesWithOptions_Signer will test the expected signer option of ValidateTapesWithOptions()
+func TestValidateTapesWithOptions_Signer(t *testing.T) {
+	t.Parallel()
+
+	tapes := parseScript(t, multiSignedScript(t, [][]byte{[]byte("hello")}, multiSigner{examplePrivateKey, BitcoinSignedMessage}))
+	for _, test := range []struct {
+		signer        string
+		expectedValid bool
+	}{
+		{"", true},
+		{exampleTestnetAddress, true},
+		{examplePubKey, true},
+		{exampleHash160, true},
+		{address(secondPrivateKey), false},
+	} {
+		result, err := ValidateTapesWithOptions(tapes, &ValidateOptions{Signer: test.signer})
+		if result.Valid != test.expectedValid {
+			t.Errorf("%s Failed: [%s] inputted and expected [%t] but got [%t], error: %v", t.Name(), test.signer, test.expectedValid, result.Valid, err)
+		}
+	}
+}
+
+// ExampleSignWithNetwork example using SignWithNetwork()
+func ExampleSignWithNetwork() {
+	a, err := SignWithNetwork(examplePrivateKey, BitcoinSignedMessage, exampleMessage, Testnet)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	valid, _ := a.ValidateSigner(exampleHash160)
+	fmt.Printf("address: %s valid: %t", a.AlgorithmSigningComponent, valid)
+	// Output:address: mtBEFNrf94fiib6zW6JZjZTFEpLK7RqN3i valid: true
+}
+
+// BenchmarkAip_ValidateSigner benchmarks the method ValidateSigner()
+func BenchmarkAip_ValidateSigner(b *testing.B) {
+	a, _ := SignWithNetwork(examplePrivateKey, BitcoinSignedMessage, exampleMessage, Testnet)
+	for i := 0; i < b.N; i++ {
+		_, _ = a.ValidateSigner(examplePubKey)
+	}
+}
@@ -0,0 +1,113 @@
+package aip
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// Stage is a step of a validation reported to the Observer
+type Stage string
+
+// Validation stages (reconstruct, recover and verify repeat for each payload interpretation tried)
+const (
+	StageParse       Stage = "parse"       // Finding the AIP tape and decoding its fields
+	StageReconstruct Stage = "reconstruct" // Rebuilding the signed payload from the tapes
+	StageRecover     Stage = "recover"     // Recovering the public key from the signature
+	StageVerify      Stage = "verify"      // Checking the recovered key against the signing component
+)
+
+// FailureReason is why a validation failed (a small fixed set, safe as a metric label)
+type FailureReason string
+
+// Failure reasons
+const (
+	FailureNone               FailureReason = ""                    // The signature is valid
+	FailureUnknownMode        FailureReason = "unknown_mode"        // The validation mode is not known
+	FailureNoAip              FailureReason = "no_aip"              // No AIP tape was found
+	FailureUnknownAlgorithm   FailureReason = "unknown_algorithm"   // The algorithm is not known
+	FailureMalformedSignature FailureReason = "malformed_signature" // The signature is not valid base64
+	FailureNoPayload          FailureReason = "no_payload"          // No signed payload could be rebuilt
+	FailureRecover            FailureReason = "recover_failed"      // No public key could be recovered
+	FailureMismatch           FailureReason = "signature_mismatch"  // The signer is not the signing component
+	FailureUncompressedKey    FailureReason = "uncompressed_key"    // Strict mode requires a compressed key
+	FailureUnexpectedSigner   FailureReason = "unexpected_signer"   // The signer is not ValidateOptions.Signer
+)
+
+// ValidationEvent is the outcome of a validation reported to the Observer
+type ValidationEvent struct {
+	Algorithm Algorithm     // The known algorithm ("unknown" if it is not known)
+	Duration  time.Duration // How long the validation took
+	Err       error         // The validation error
+	Mode      Mode          // The mode used to validate
+	Reason    FailureReason // Why the validation failed
+	Valid     bool          // True if the signature is valid
+}
+
+// Observer receives instrumentation from ValidateTapesWithContext
+//
+// Implementations must be safe for concurrent use. The aipotel package turns
+// the events into OpenTelemetry spans and metrics.
+type Observer interface {
+	// StartValidation is called when a validation starts, end is called once with its outcome
+	StartValidation(ctx context.Context) (context.Context, func(event ValidationEvent))
+
+	// StartStage is called when a stage starts, end is called once with the stage error
+	StartStage(ctx context.Context, stage Stage) (context.Context, func(err error))
+}
+
+// attemptObserver also receives the data of each payload interpretation tried (used by Explain)
+type attemptObserver interface {
+	Observer
+
+	// attempt is called before the stages of an interpretation
+	attempt(name Interpretation)
+
+	// payload is called with the reconstructed data
+	payload(data []string)
+
+	// recovered is called with the recovered public key
+	recovered(pubKey *ec.PublicKey, compressed bool)
+}
+
+// NopObserver is an Observer that does nothing (the default)
+type NopObserver struct{}
+
+// StartValidation implements Observer
+func (NopObserver) StartValidation(ctx context.Context) (context.Context, func(ValidationEvent)) {
+	return ctx, func(ValidationEvent) {}
+}
+
+// StartStage implements Observer
+func (NopObserver) StartStage(ctx context.Context, _ Stage) (context.Context, func(error)) {
+	return ctx, func(error) {}
+}
+
+// globalObserver holds the observer used when the options do not set one
+var globalObserver atomic.Value
+
+// SetObserver sets the observer used when ValidateOptions.Observer is nil (nil restores the no-op default)
+func SetObserver(o Observer) {
+	if o == nil {
+		o = NopObserver{}
+	}
+	globalObserver.Store(&o)
+}
+
+// currentObserver returns the observer set with SetObserver
+func currentObserver() Observer {
+	if o, ok := globalObserver.Load().(*Observer); ok {
+		return *o
+	}
+	return NopObserver{}
+}
+
+// observeStage runs fn as a stage of the validation
+func observeStage(ctx context.Context, o Observer, stage Stage, fn func() error) error {
+	_, end := o.StartStage(ctx, stage)
+	err := fn()
+	end(err)
+	return err
+}
@@ -0,0 +1,173 @@
+package aip
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bitcoinschema/go-bpu"
+)
+
+// recordingObserver records the stages and the outcome of validations
+type recordingObserver struct {
+	mu     sync.Mutex
+	stages []string
+	events []ValidationEvent
+}
+
+// StartValidation implements Observer
+func (o *recordingObserver) StartValidation(ctx context.Context) (context.Context, func(ValidationEvent)) {
+	return ctx, func(event ValidationEvent) {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		o.events = append(o.events, event)
+	}
+}
+
+// StartStage implements Observer
+func (o *recordingObserver) StartStage(ctx context.Context, stage Stage) (context.Context, func(error)) {
+	return ctx, func(err error) {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		if err != nil {
+			o.stages = append(o.stages, string(stage)+"!")
+			return
+		}
+		o.stages = append(o.stages, string(stage))
+	}
+}
+
+// TestValidateTapesWithContext will test the instrumentation of ValidateTapesWithContext()
+func TestValidateTapesWithContext(t *testing.T) {
+	t.Parallel()
+
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var bobInvalidData *bob.Tx
+	if bobInvalidData, err = bob.NewFromString(sampleInvalidBobTx); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	unknownAlgorithm := lowercaseTapes(t)
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name              string
+			inputTapes        []bpu.Tape
+			inputOptions      ValidateOptions
+			expectedAlgorithm Algorithm
+			expectedReason    FailureReason
+			expectedStages    string
+		}{
+			{
+				"valid", bobValidData.Out[0].Tape, ValidateOptions{},
+				BitcoinECDSA, FailureNone, "parse reconstruct recover verify",
+			},
+			{
+				"legacy interpretation", legacyTapes(t, []string{" hello ", pipe}, "hello"+pipe, BitcoinECDSA), ValidateOptions{Mode: ModeLegacy},
+				BitcoinECDSA, FailureNone, "parse reconstruct recover verify! reconstruct recover verify",
+			},
+			{
+				"mismatch", bobInvalidData.Out[0].Tape, ValidateOptions{},
+				BitcoinECDSA, FailureMismatch, "parse reconstruct recover verify!",
+			},
+			{
+				"uncompressed", uncompressedTapes(t), ValidateOptions{},
+				BitcoinECDSA, FailureUncompressedKey, "parse reconstruct recover verify!",
+			},
+			{
+				"unexpected signer", bobValidData.Out[0].Tape, ValidateOptions{Signer: address(secondPrivateKey)},
+				BitcoinECDSA, FailureUnexpectedSigner, "parse reconstruct recover verify!",
+			},
+			{
+				"unknown algorithm", unknownAlgorithm, ValidateOptions{},
+				"unknown", FailureUnknownAlgorithm, "parse!",
+			},
+			{
+				"no aip", []bpu.Tape{*new(bpu.Tape)}, ValidateOptions{},
+				"unknown", FailureNoAip, "parse!",
+			},
+			{
+				"unknown mode", bobValidData.Out[0].Tape, ValidateOptions{Mode: "loose"},
+				"unknown", FailureUnknownMode, "",
+			},
+		}
+	)
+
+	for _, test := range tests {
+		o := new(recordingObserver)
+		test.inputOptions.Observer = o
+		result, err := ValidateTapesWithContext(context.Background(), test.inputTapes, &test.inputOptions)
+		if len(o.events) != 1 {
+			t.Fatalf("%s Failed: [%s] expected 1 event but got %d", t.Name(), test.name, len(o.events))
+		}
+		event := o.events[0]
+		if event.Algorithm != test.expectedAlgorithm || event.Reason != test.expectedReason {
+			t.Errorf("%s Failed: [%s] expected [%s %s] but got [%s %s]", t.Name(), test.name,
+				test.expectedAlgorithm, test.expectedReason, event.Algorithm, event.Reason)
+		} else if event.Valid != (test.expectedReason == FailureNone) || event.Err != err || (result != nil && result.Valid != event.Valid) {
+			t.Errorf("%s Failed: [%s] event [%v] does not match the result", t.Name(), test.name, event)
+		}
+		if stages := strings.Join(o.stages, " "); stages != test.expectedStages {
+			t.Errorf("%s Failed: [%s] expected stages [%s] but got [%s]", t.Name(), test.name, test.expectedStages, stages)
+		}
+	}
+}
+
+// TestSetObserver will test the method SetObserver()
+func TestSetObserver(t *testing.T) {
+	// Not parallel: the observer is global
+
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	o := new(recordingObserver)
+	SetObserver(o)
+	defer SetObserver(nil)
+
+	if _, err = ValidateTapesWithOptions(bobValidData.Out[0].Tape, nil); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	o.mu.Lock()
+	count := len(o.events)
+	o.mu.Unlock()
+	if count == 0 {
+		t.Errorf("%s Failed: expected the global observer to be called", t.Name())
+	}
+
+	SetObserver(nil)
+	if _, ok := currentObserver().(NopObserver); !ok {
+		t.Errorf("%s Failed: expected the no-op observer to be restored", t.Name())
+	}
+}
+
+// ExampleNopObserver example using an Observer with ValidateTapesWithContext()
+func ExampleNopObserver() {
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	var result *ValidationResult
+	if result, err = ValidateTapesWithContext(context.Background(), bobValidData.Out[0].Tape,
+		&ValidateOptions{Observer: NopObserver{}}); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("valid: %t signer: %s", result.Valid, result.Signer)
+	// Output:valid: true signer: 134a6TXxzgQ9Az3w8BcvgdZyA5UqRL89da
+}
+
+// BenchmarkValidateTapesWithContext benchmarks the method ValidateTapesWithContext() with the no-op observer
+func BenchmarkValidateTapesWithContext(b *testing.B) {
+	bobValidData, _ := bob.NewFromString(sampleValidBobTx)
+	for i := 0; i < b.N; i++ {
+		_, _ = ValidateTapesWithContext(context.Background(), bobValidData.Out[0].Tape, nil)
+	}
+}
@@ -0,0 +1,142 @@
+package aip
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bitcoinschema/go-bpu"
+)
+
+// Policy requires valid signatures from a threshold of known signers (m-of-n)
+//
+// Signers are addresses, or identity keys (hex) for the paymail algorithm
+type Policy struct {
+	Signers   []string `json:"signers"`   // The accepted signers
+	Threshold int      `json:"threshold"` // Minimum number of distinct signers required
+}
+
+// PolicySignature is the evaluation of a single AIP against a policy
+type PolicySignature struct {
+	Aip      *Aip   `json:"aip"`              // The evaluated AIP
+	Covers   bool   `json:"covers"`           // True if the signed data covers the content
+	Error    string `json:"error,omitempty"`  // Why the signature is invalid
+	Required string `json:"required"`         // The policy signer it matched (if any)
+	Signer   string `json:"signer,omitempty"` // The address recovered from the signature
+	Valid    bool   `json:"valid"`            // True if the signature is valid
+}
+
+// PolicyResult is the decision of a policy evaluation
+type PolicyResult struct {
+	Passed     bool               `json:"passed"`     // True if the threshold was met
+	Signatures []*PolicySignature `json:"signatures"` // Every evaluated AIP (in order)
+	Signers    []string           `json:"signers"`    // Distinct policy signers that contributed
+	Threshold  int                `json:"threshold"`  // The threshold that was required
+}
+
+// NewPolicy will create a new m-of-n policy
+func NewPolicy(threshold int, signers ...string) (*Policy, error) {
+	p := &Policy{Signers: signers, Threshold: threshold}
+	if err := p.check(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// check returns an error if the policy can never be evaluated
+func (p *Policy) check() error {
+	if len(p.Signers) == 0 {
+		return errors.New("policy requires at least one signer")
+	} else if p.Threshold < 1 || p.Threshold > len(p.Signers) {
+		return fmt.Errorf("threshold must be between 1 and %d, got: %d", len(p.Signers), p.Threshold)
+	}
+	return nil
+}
+
+// Evaluate checks the AIPs (as returned by NewFromAllTapes) against the policy
+//
+// The content is the data signed by the first AIP. Each later AIP signs the
+// content plus every earlier signature, so an AIP contributes when it is valid,
+// its signed data starts with the content and its signer is in the policy.
+// A signer only counts once, no matter how many times they signed.
+func (p *Policy) Evaluate(aips []*Aip) (*PolicyResult, error) {
+	if err := p.check(); err != nil {
+		return nil, err
+	}
+	result := &PolicyResult{Threshold: p.Threshold}
+	if len(aips) == 0 {
+		return result, nil
+	}
+
+	content := aips[0].Data
+	contributed := make(map[string]bool)
+	for _, a := range aips {
+		s := &PolicySignature{Aip: a, Covers: hasPrefix(a.Data, content)}
+		result.Signatures = append(result.Signatures, s)
+
+		var err error
+		if s.Signer, err = a.signer(); err != nil {
+			s.Error = err.Error()
+			continue
+		}
+		s.Valid = true
+		s.Required = p.match(a, s.Signer)
+		if s.Required == "" || !s.Covers || contributed[s.Required] {
+			continue
+		}
+		contributed[s.Required] = true
+		result.Signers = append(result.Signers, s.Required)
+	}
+	result.Passed = len(result.Signers) >= p.Threshold
+	return result, nil
+}
+
+// EvaluateTapes checks every AIP in the tapes against the policy
+//
+// The signed data is rebuilt from the exact cells (the spec interpretation), so
+// whitespace that was signed is kept and whitespace that was not is never trusted
+func (p *Policy) EvaluateTapes(tapes []bpu.Tape) (*PolicyResult, error) {
+	return p.Evaluate(allFromTapes(tapes, specReconstruction))
+}
+
+// match returns the policy signer matching the AIP (address or identity key)
+func (p *Policy) match(a *Aip, signer string) string {
+	for _, required := range p.Signers {
+		if required == signer || (a.Algorithm == Paymail && strings.EqualFold(required, a.AlgorithmSigningComponent)) {
+			return required
+		}
+	}
+	return ""
+}
+
+// signer validates the AIP (without modifying it) and returns the recovered address
+func (a *Aip) signer() (string, error) {
+	if len(a.Data) == 0 || a.Data[0] != opReturn {
+		return "", errors.New("missing data")
+	}
+	algorithm, err := normalizeAlgorithm(a.Algorithm, ModeStrict)
+	if err != nil {
+		return "", err
+	}
+	var sig []byte
+	if sig, err = base64.StdEncoding.DecodeString(a.Signature); err != nil {
+		return "", err
+	}
+	var signer string
+	signer, _, err = verifySigner(algorithm, a.AlgorithmSigningComponent, sig, []byte(strings.Join(a.Data, "")))
+	return signer, err
+}
+
+// hasPrefix returns true if data starts with every item of prefix
+func hasPrefix(data, prefix []string) bool {
+	if len(data) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if data[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
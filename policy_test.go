@@ -0,0 +1,250 @@
+package aip
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bitcoinschema/go-bpu"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Additional keys used for multi-signature outputs
+var (
+	secondPrivateKey, _ = ec.PrivateKeyFromHex("e83385af76b2b1997326b567461fb73dd9c27eab9e1e86d26779f4650c5f2b75")
+	thirdPrivateKey, _  = ec.PrivateKeyFromHex("0499f8239bfe10eb0f5e53d543635a423c96529dd85fa4bad42049a0b435ebdd")
+)
+
+// multiSigner is a key and algorithm used by multiSignedScript
+type multiSigner struct {
+	key       *ec.PrivateKey
+	algorithm Algorithm
+}
+
+// parseScript parses the script as a single output with BOB
+func parseScript(t testing.TB, s *script.Script) []bpu.Tape {
+	tapes, err := scriptTapes(s)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return tapes
+}
+
+// scriptTapes is parseScript returning the error (for examples)
+func scriptTapes(s *script.Script) ([]bpu.Tape, error) {
+	tx := transaction.NewTransaction()
+	tx.AddOutput(&transaction.TransactionOutput{LockingScript: s})
+	bobTx, err := bob.NewFromTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return bobTx.Out[0].Tape, nil
+}
+
+// multiSignedScript builds OP_FALSE OP_RETURN <parts> | <AIP> | <AIP> ... where
+// every AIP signs the exact cells before it (the spec interpretation)
+func multiSignedScript(t testing.TB, parts [][]byte, signers ...multiSigner) *script.Script {
+	s, err := newMultiSignedScript(parts, signers...)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return s
+}
+
+// newMultiSignedScript is multiSignedScript returning the error (for examples)
+func newMultiSignedScript(parts [][]byte, signers ...multiSigner) (*script.Script, error) {
+	pushes := append(append([][]byte{}, parts...), []byte(pipe))
+	build := func(pushes [][]byte) *script.Script {
+		s := &script.Script{}
+		_ = s.AppendOpcodes(script.OpFALSE, script.OpRETURN)
+		_ = s.AppendPushDataArray(pushes)
+		return s
+	}
+
+	for i, signer := range signers {
+		if i > 0 {
+			pushes = append(pushes, []byte(pipe))
+		}
+
+		// Parse with a placeholder signature to find the data this AIP signs
+		placeholder := append(append([][]byte{}, pushes...), []byte(Prefix), []byte(signer.algorithm), []byte("-"), []byte("-"))
+		tapes, err := scriptTapes(build(placeholder))
+		if err != nil {
+			return nil, err
+		}
+		aips := allFromTapes(tapes, specReconstruction)
+		var a *Aip
+		if a, err = Sign(signer.key, signer.algorithm, strings.Join(aips[i].Data[1:], "")); err != nil {
+			return nil, err
+		}
+		var aipPushes [][]byte
+		if aipPushes, err = a.MarshalPushes(); err != nil {
+			return nil, err
+		}
+		pushes = append(pushes, aipPushes...)
+	}
+	return build(pushes), nil
+}
+
+// address returns the compressed mainnet address of the key
+func address(key *ec.PrivateKey) string {
+	addr, _ := script.NewAddressFromPublicKey(key.PubKey(), true)
+	return addr.AddressString
+}
+
+// TestPolicy_Evaluate will test the method Evaluate()
+func TestPolicy_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	content := [][]byte{[]byte("19HxigV4QyBv3tHpQVcUEQyq1pzZVdoAut"), []byte("hello world"), []byte("text/plain")}
+	first, second, third := address(examplePrivateKey), address(secondPrivateKey), address(thirdPrivateKey)
+	outsider, _ := ec.NewPrivateKey()
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name            string
+			inputSigners    []multiSigner
+			inputThreshold  int
+			expectedPassed  bool
+			expectedSigners []string
+		}{
+			{"2 of 3 signed", []multiSigner{{examplePrivateKey, BitcoinECDSA}, {secondPrivateKey, BitcoinECDSA}}, 2, true, []string{first, second}},
+			{"3 of 3 signed", []multiSigner{{examplePrivateKey, BitcoinECDSA}, {secondPrivateKey, BitcoinSignedMessage}, {thirdPrivateKey, BitcoinECDSA}}, 2, true, []string{first, second, third}},
+			{"1 of 3 signed", []multiSigner{{examplePrivateKey, BitcoinECDSA}}, 2, false, []string{first}},
+			{"same signer twice", []multiSigner{{examplePrivateKey, BitcoinECDSA}, {examplePrivateKey, BitcoinECDSA}}, 2, false, []string{first}},
+			{"outsider signed", []multiSigner{{outsider, BitcoinECDSA}, {thirdPrivateKey, BitcoinECDSA}}, 2, false, []string{third}},
+			{"paymail identity key", []multiSigner{{secondPrivateKey, Paymail}, {thirdPrivateKey, BitcoinECDSA}}, 2, true, []string{second, third}},
+		}
+	)
+
+	p, err := NewPolicy(2, first, second, third)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	for _, test := range tests {
+		p.Threshold = test.inputThreshold
+		tapes := parseScript(t, multiSignedScript(t, content, test.inputSigners...))
+
+		var result *PolicyResult
+		if result, err = p.EvaluateTapes(tapes); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if result.Passed != test.expectedPassed {
+			t.Errorf("%s Failed: [%s] expected [%t] but got [%t]", t.Name(), test.name, test.expectedPassed, result.Passed)
+		} else if fmt.Sprint(result.Signers) != fmt.Sprint(test.expectedSigners) {
+			t.Errorf("%s Failed: [%s] expected [%v] but got [%v]", t.Name(), test.name, test.expectedSigners, result.Signers)
+		} else if len(result.Signatures) != len(test.inputSigners) {
+			t.Errorf("%s Failed: [%s] expected [%d] signatures but got [%d]", t.Name(), test.name, len(test.inputSigners), len(result.Signatures))
+		}
+	}
+}
+
+// TestPolicy_EvaluateTapes_Whitespace will test EvaluateTapes() keeps signed whitespace
+func TestPolicy_EvaluateTapes_Whitespace(t *testing.T) {
+	t.Parallel()
+
+	tapes := parseScript(t, multiSignedScript(t, [][]byte{[]byte(" hello world\n")},
+		multiSigner{examplePrivateKey, BitcoinECDSA}, multiSigner{secondPrivateKey, BitcoinECDSA},
+	))
+	p, _ := NewPolicy(2, address(examplePrivateKey), address(secondPrivateKey))
+
+	result, err := p.EvaluateTapes(tapes)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if !result.Passed {
+		t.Errorf("%s Failed: expected the policy to pass, signatures: %+v", t.Name(), result.Signatures)
+	} else if result.Signatures[0].Aip.Data[1] != " hello world\n" {
+		t.Errorf("%s Failed: expected the untrimmed content but got [%q]", t.Name(), result.Signatures[0].Aip.Data[1])
+	}
+}
+
+// TestPolicy_Evaluate_Invalid will test Evaluate() with invalid and uncovered signatures
+func TestPolicy_Evaluate_Invalid(t *testing.T) {
+	t.Parallel()
+
+	content := [][]byte{[]byte("hello world")}
+	first, second := address(examplePrivateKey), address(secondPrivateKey)
+	aips := NewFromAllTapes(parseScript(t, multiSignedScript(t, content,
+		multiSigner{examplePrivateKey, BitcoinECDSA}, multiSigner{secondPrivateKey, BitcoinECDSA},
+	)))
+	p, _ := NewPolicy(2, first, second)
+
+	// Tampered signature
+	tampered := *aips[1]
+	tampered.Data = append(append([]string{}, aips[1].Data...), "extra")
+	result, err := p.Evaluate([]*Aip{aips[0], &tampered})
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if result.Passed || result.Signatures[1].Valid || result.Signatures[1].Error == "" {
+		t.Errorf("%s Failed: expected the tampered signature to fail", t.Name())
+	}
+
+	// A valid signature over other content does not count
+	other := NewFromAllTapes(parseScript(t, multiSignedScript(t, [][]byte{[]byte("other")},
+		multiSigner{secondPrivateKey, BitcoinECDSA},
+	)))
+	if result, err = p.Evaluate([]*Aip{aips[0], other[0]}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if result.Passed || !result.Signatures[1].Valid || result.Signatures[1].Covers {
+		t.Errorf("%s Failed: expected the signature over other content not to count", t.Name())
+	}
+
+	// No AIPs at all
+	if result, err = p.Evaluate(nil); err != nil || result.Passed {
+		t.Errorf("%s Failed: expected no decision to pass [%v]", t.Name(), err)
+	}
+
+	// Invalid policies
+	for _, invalid := range []*Policy{{}, {Signers: []string{first}}, {Signers: []string{first}, Threshold: 2}} {
+		if _, err = invalid.Evaluate(aips); err == nil {
+			t.Errorf("%s Failed: error was expected for policy [%v]", t.Name(), invalid)
+		}
+	}
+	if _, err = NewPolicy(0, first); err == nil {
+		t.Errorf("%s Failed: error was expected", t.Name())
+	}
+}
+
+// ExamplePolicy_Evaluate example using Evaluate()
+func ExamplePolicy_Evaluate() {
+	first, second := address(examplePrivateKey), address(secondPrivateKey)
+	s, err := newMultiSignedScript([][]byte{[]byte("hello world")},
+		multiSigner{examplePrivateKey, BitcoinECDSA}, multiSigner{secondPrivateKey, BitcoinECDSA},
+	)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	var tapes []bpu.Tape
+	if tapes, err = scriptTapes(s); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	var p *Policy
+	if p, err = NewPolicy(2, first, second, address(thirdPrivateKey)); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	var result *PolicyResult
+	if result, err = p.Evaluate(NewFromAllTapes(tapes)); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("passed: %t signers: %d", result.Passed, len(result.Signers))
+	// Output:passed: true signers: 2
+}
+
+// BenchmarkPolicy_Evaluate benchmarks the method Evaluate()
+func BenchmarkPolicy_Evaluate(b *testing.B) {
+	aips := NewFromAllTapes(parseScript(b, multiSignedScript(b, [][]byte{[]byte("hello world")},
+		multiSigner{examplePrivateKey, BitcoinECDSA}, multiSigner{secondPrivateKey, BitcoinECDSA},
+	)))
+	p, _ := NewPolicy(2, address(examplePrivateKey), address(secondPrivateKey))
+	for i := 0; i < b.N; i++ {
+		_, _ = p.Evaluate(aips)
+	}
+}
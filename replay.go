@@ -0,0 +1,193 @@
+package aip
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bitcoinschema/go-bpu"
+)
+
+// MapPrefix is the Bitcom prefix used by MAP
+const MapPrefix = "1PuQa7K62MiKCtssSLKy1kh56WWU7MtUR5"
+
+// MAP keys used for replay protection
+const (
+	NonceKey     = "aip_nonce"     // Random value that is only accepted once per signer
+	TimestampKey = "aip_timestamp" // Unix time (seconds) when the data was signed
+)
+
+// WithReplayProtection appends a MAP SET segment holding a timestamp and a nonce
+//
+// The result ends with a pipe and is ready for SignOpReturnData, so both fields
+// are part of the signed data. An empty nonce or a zero timestamp is left out.
+func WithReplayProtection(data [][]byte, timestamp time.Time, nonce string) [][]byte {
+	out := append([][]byte{}, data...)
+	if len(out) > 0 && string(out[len(out)-1]) != pipe {
+		out = append(out, []byte(pipe))
+	}
+	out = append(out, []byte(MapPrefix), []byte("SET"))
+	if !timestamp.IsZero() {
+		out = append(out, []byte(TimestampKey), []byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	}
+	if nonce != "" {
+		out = append(out, []byte(NonceKey), []byte(nonce))
+	}
+	return append(out, []byte(pipe))
+}
+
+// NewNonce returns a random 128-bit nonce (hex)
+func NewNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ReplayFields are the replay protection fields found in signed data
+type ReplayFields struct {
+	Nonce     string    `json:"nonce,omitempty"`     // The nonce (empty if missing)
+	Timestamp time.Time `json:"timestamp,omitempty"` // The timestamp (zero if missing)
+}
+
+// ReplayFields returns the replay protection fields from the signed data (a.Data)
+//
+// Only MAP SET segments inside the signed data are read, so fields added after
+// signing are never trusted.
+func (a *Aip) ReplayFields() (*ReplayFields, error) {
+	fields := new(ReplayFields)
+	for i := 0; i+1 < len(a.Data); i++ {
+		if a.Data[i] != MapPrefix || a.Data[i+1] != "SET" {
+			continue
+		}
+		for j := i + 2; j+1 < len(a.Data); j += 2 {
+			key, value := a.Data[j], a.Data[j+1]
+			if key == pipe || key == MapPrefix || key == Prefix {
+				break
+			}
+			switch key {
+			case TimestampKey:
+				seconds, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s: %s", TimestampKey, value)
+				}
+				fields.Timestamp = time.Unix(seconds, 0)
+			case NonceKey:
+				fields.Nonce = value
+			}
+		}
+	}
+	return fields, nil
+}
+
+// NonceStore remembers the nonces that were already accepted
+type NonceStore interface {
+	// Seen records the nonce for the signer (until expires, zero = forever)
+	// and returns true if it was already recorded
+	Seen(signer, nonce string, expires time.Time) (bool, error)
+}
+
+// MemoryNonceStore is an in-memory NonceStore, safe for concurrent use
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	now    func() time.Time
+}
+
+// NewMemoryNonceStore will create a new in-memory nonce store
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{nonces: make(map[string]time.Time), now: time.Now}
+}
+
+// Seen implements NonceStore, expired nonces are removed on each call
+func (s *MemoryNonceStore) Seen(signer, nonce string, expires time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, e := range s.nonces {
+		if !e.IsZero() && now.After(e) {
+			delete(s.nonces, key)
+		}
+	}
+	key := signer + pipe + nonce
+	if _, found := s.nonces[key]; found {
+		return true, nil
+	}
+	s.nonces[key] = expires
+	return false, nil
+}
+
+// ReplayOptions are the options used by ValidateReplay
+type ReplayOptions struct {
+	MaxAge   time.Duration    // Oldest accepted timestamp (0 = timestamp not required)
+	MaxSkew  time.Duration    // How far in the future a timestamp may be
+	Now      func() time.Time // Clock (defaults to time.Now)
+	Store    NonceStore       // Seen nonces (nil = nonce not required)
+	Validate *ValidateOptions // Options for the signature validation
+}
+
+// ReplayResult is the outcome of ValidateReplay
+type ReplayResult struct {
+	Fields     *ReplayFields     `json:"fields,omitempty"`     // The signed replay protection fields
+	Validation *ValidationResult `json:"validation,omitempty"` // The signature validation
+}
+
+// ValidateReplay validates the AIP signature and enforces freshness and uniqueness
+//
+// The timestamp must not be older than MaxAge (or further than MaxSkew in the
+// future) and the nonce must not have been seen before for the same signer.
+func ValidateReplay(tapes []bpu.Tape, opts *ReplayOptions) (*ReplayResult, error) {
+	if opts == nil {
+		opts = &ReplayOptions{}
+	}
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+
+	result := new(ReplayResult)
+	var err error
+	if result.Validation, err = ValidateTapesWithOptions(tapes, opts.Validate); err != nil {
+		return result, err
+	}
+	if result.Fields, err = result.Validation.Aip.ReplayFields(); err != nil {
+		return result, err
+	}
+
+	// Freshness
+	ts := result.Fields.Timestamp
+	if opts.MaxAge > 0 {
+		if ts.IsZero() {
+			return result, errors.New("missing signed timestamp")
+		} else if now().Sub(ts) > opts.MaxAge {
+			return result, fmt.Errorf("signed timestamp is too old: %s", ts.UTC().Format(time.RFC3339))
+		}
+	}
+	if !ts.IsZero() && ts.Sub(now()) > opts.MaxSkew {
+		return result, fmt.Errorf("signed timestamp is in the future: %s", ts.UTC().Format(time.RFC3339))
+	}
+
+	// Uniqueness
+	if opts.Store != nil {
+		if result.Fields.Nonce == "" {
+			return result, errors.New("missing signed nonce")
+		}
+		var expires time.Time
+		if opts.MaxAge > 0 {
+			expires = ts.Add(opts.MaxAge)
+		}
+		var seen bool
+		if seen, err = opts.Store.Seen(result.Validation.Signer, result.Fields.Nonce, expires); err != nil {
+			return result, err
+		} else if seen {
+			return result, fmt.Errorf("nonce already used: %s", result.Fields.Nonce)
+		}
+	}
+	return result, nil
+}
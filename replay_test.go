@@ -0,0 +1,190 @@
+package aip
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bitcoinschema/go-bpu"
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// replayTapes signs the content with replay protection fields and parses it with BOB
+func replayTapes(t testing.TB, timestamp time.Time, nonce string) []bpu.Tape {
+	tapes, err := newReplayTapes(timestamp, nonce)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return tapes
+}
+
+// newReplayTapes is replayTapes returning the error (for examples)
+func newReplayTapes(timestamp time.Time, nonce string) ([]bpu.Tape, error) {
+	data := WithReplayProtection([][]byte{[]byte("19HxigV4QyBv3tHpQVcUEQyq1pzZVdoAut"), []byte("hello world")}, timestamp, nonce)
+	outData, _, err := SignOpReturnData(examplePrivateKey, BitcoinECDSA, data)
+	if err != nil {
+		return nil, err
+	}
+	s := &script.Script{}
+	_ = s.AppendOpcodes(script.OpFALSE, script.OpRETURN)
+	_ = s.AppendPushDataArray(outData)
+	return scriptTapes(s)
+}
+
+// TestWithReplayProtection will test the method WithReplayProtection()
+func TestWithReplayProtection(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Unix(1700000000, 0)
+	var (
+		// Testing private methods
+		tests = []struct {
+			name           string
+			inputData      [][]byte
+			inputTimestamp time.Time
+			inputNonce     string
+			expectedData   string
+		}{
+			{"timestamp and nonce", [][]byte{[]byte("a")}, ts, "abc", "a|" + MapPrefix + "SET" + TimestampKey + "1700000000" + NonceKey + "abc|"},
+			{"already piped", [][]byte{[]byte("a"), []byte(pipe)}, ts, "", "a|" + MapPrefix + "SET" + TimestampKey + "1700000000|"},
+			{"nonce only", nil, time.Time{}, "abc", MapPrefix + "SET" + NonceKey + "abc|"},
+		}
+	)
+
+	for _, test := range tests {
+		var joined string
+		for _, d := range WithReplayProtection(test.inputData, test.inputTimestamp, test.inputNonce) {
+			joined += string(d)
+		}
+		if joined != test.expectedData {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%s]", t.Name(), test.name, test.expectedData, joined)
+		}
+	}
+
+	nonce, err := NewNonce()
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if other, _ := NewNonce(); len(nonce) != 32 || nonce == other {
+		t.Errorf("%s Failed: expected unique 32 character nonces but got [%s] [%s]", t.Name(), nonce, other)
+	}
+}
+
+// TestValidateReplay will test the method ValidateReplay()
+func TestValidateReplay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name           string
+			inputTimestamp time.Time
+			inputNonce     string
+			inputOptions   *ReplayOptions
+			expectedError  bool
+		}{
+			{"fresh", now.Add(-time.Minute), "n1", &ReplayOptions{MaxAge: time.Hour, Now: clock, Store: NewMemoryNonceStore()}, false},
+			{"no requirements", time.Time{}, "", &ReplayOptions{Now: clock}, false},
+			{"too old", now.Add(-2 * time.Hour), "n1", &ReplayOptions{MaxAge: time.Hour, Now: clock}, true},
+			{"in the future", now.Add(time.Hour), "n1", &ReplayOptions{MaxAge: time.Hour, MaxSkew: time.Minute, Now: clock}, true},
+			{"within skew", now.Add(30 * time.Second), "n1", &ReplayOptions{MaxAge: time.Hour, MaxSkew: time.Minute, Now: clock}, false},
+			{"missing timestamp", time.Time{}, "n1", &ReplayOptions{MaxAge: time.Hour, Now: clock}, true},
+			{"missing nonce", now, "", &ReplayOptions{Now: clock, Store: NewMemoryNonceStore()}, true},
+		}
+	)
+
+	for _, test := range tests {
+		result, err := ValidateReplay(replayTapes(t, test.inputTimestamp, test.inputNonce), test.inputOptions)
+		if err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] error was expected", t.Name(), test.name)
+		} else if err == nil && (result.Fields.Nonce != test.inputNonce || !result.Fields.Timestamp.Equal(test.inputTimestamp)) {
+			t.Errorf("%s Failed: [%s] expected [%s] [%s] but got [%v]", t.Name(), test.name, test.inputNonce, test.inputTimestamp, result.Fields)
+		}
+	}
+
+	// The SignOpReturnData output validates in every mode
+	for _, mode := range []Mode{ModeStrict, ModeLegacy} {
+		if _, err := ValidateReplay(replayTapes(t, now, ""), &ReplayOptions{Now: clock, Validate: &ValidateOptions{Mode: mode}}); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), mode, err.Error())
+		}
+	}
+	if valid, err := ValidateTapes(replayTapes(t, now, "n1")); !valid || err != nil {
+		t.Errorf("%s Failed: expected valid tapes but got [%t] [%v]", t.Name(), valid, err)
+	}
+
+	// The same nonce is rejected the second time
+	store := NewMemoryNonceStore()
+	store.now = clock
+	opts := &ReplayOptions{MaxAge: time.Hour, Now: clock, Store: store}
+	tapes := replayTapes(t, now, "once")
+	if _, err := ValidateReplay(tapes, opts); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	if _, err := ValidateReplay(tapes, opts); err == nil {
+		t.Errorf("%s Failed: error was expected (replayed nonce)", t.Name())
+	}
+
+	// Invalid signatures are rejected before the replay checks
+	tapes[1].Cell[0].S = &[]string{"changed"}[0]
+	if _, err := ValidateReplay(tapes, nil); err == nil {
+		t.Errorf("%s Failed: error was expected (invalid signature)", t.Name())
+	}
+}
+
+// TestMemoryNonceStore will test the method Seen()
+func TestMemoryNonceStore(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryNonceStore()
+	store.now = func() time.Time { return now }
+
+	if seen, _ := store.Seen("a", "1", now.Add(time.Minute)); seen {
+		t.Errorf("%s Failed: nonce should not have been seen", t.Name())
+	}
+	if seen, _ := store.Seen("a", "1", now.Add(time.Minute)); !seen {
+		t.Errorf("%s Failed: nonce should have been seen", t.Name())
+	}
+	if seen, _ := store.Seen("b", "1", time.Time{}); seen {
+		t.Errorf("%s Failed: nonce should be per signer", t.Name())
+	}
+
+	// Expired nonces are forgotten
+	now = now.Add(2 * time.Minute)
+	if seen, _ := store.Seen("a", "1", now.Add(time.Minute)); seen {
+		t.Errorf("%s Failed: expired nonce should have been removed", t.Name())
+	}
+	if seen, _ := store.Seen("b", "1", time.Time{}); !seen {
+		t.Errorf("%s Failed: nonce without expiry should be kept", t.Name())
+	}
+}
+
+// ExampleValidateReplay example using ValidateReplay()
+func ExampleValidateReplay() {
+	tapes, err := newReplayTapes(time.Now(), "3f2a9c")
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	opts := &ReplayOptions{MaxAge: time.Hour, Store: NewMemoryNonceStore()}
+
+	_, err = ValidateReplay(tapes, opts)
+	fmt.Printf("first: %v\n", err)
+	_, err = ValidateReplay(tapes, opts)
+	fmt.Printf("replayed: %v", err)
+	// Output:first: <nil>
+	// replayed: nonce already used: 3f2a9c
+}
+
+// BenchmarkValidateReplay benchmarks the method ValidateReplay()
+func BenchmarkValidateReplay(b *testing.B) {
+	tapes := replayTapes(b, time.Now(), "")
+	opts := &ReplayOptions{MaxAge: time.Hour}
+	for i := 0; i < b.N; i++ {
+		_, _ = ValidateReplay(tapes, opts)
+	}
+}
@@ -0,0 +1,216 @@
+package aip
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bitcoinschema/go-bpu"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Record is the canonical JSON shape of an AIP located in a transaction
+//
+// Data is hex encoded so binary pushes survive the JSON round trip
+type Record struct {
+	TxID                      string            `json:"txid"`                         // Transaction ID
+	Vout                      uint32            `json:"vout"`                         // Output index
+	TapeIndex                 int               `json:"tape_index"`                   // Index of the AIP tape in the output
+	Algorithm                 Algorithm         `json:"algorithm"`                    // Known AIP algorithm type
+	AlgorithmSigningComponent string            `json:"algorithm_signing_component"`  // Changes based on the Algorithm
+	Signature                 string            `json:"signature"`                    // AIP generated signature
+	SignatureEncoding         SignatureEncoding `json:"signature_encoding,omitempty"` // How the signature cell was pushed
+	Indices                   []int             `json:"indices,omitempty"`            // BOB indices
+	Data                      []string          `json:"data"`                         // Hex encoded data that was signed (the exact payload, cells are not trimmed)
+	Valid                     bool              `json:"valid"`                        // True if the signature is valid
+	Signer                    string            `json:"signer,omitempty"`             // Address recovered from the signature
+	Protocols                 []string          `json:"protocols,omitempty"`          // Bitcom prefixes covered by the signature
+}
+
+// NewRecord will create a new Record from an AIP and its location
+//
+// The AIP is validated over its Data, the signer is only set if it is valid
+func NewRecord(a *Aip, txID string, vout uint32, tapeIndex int) *Record {
+	r := newRecord(a, txID, vout, tapeIndex)
+
+	// Validate a copy (Validate overloads the component for paymail)
+	c := *a
+	if r.Valid, _ = c.Validate(); r.Valid {
+		sig, _ := base64.StdEncoding.DecodeString(a.Signature)
+		r.Signer, _, _ = recoverAddress(sig, []byte(strings.Join(a.Data, "")), componentNetwork(a.AlgorithmSigningComponent))
+	}
+	return r
+}
+
+// newRecord returns the record of the AIP fields and location (not validated)
+func newRecord(a *Aip, txID string, vout uint32, tapeIndex int) *Record {
+	r := &Record{
+		TxID:                      txID,
+		Vout:                      vout,
+		TapeIndex:                 tapeIndex,
+		Algorithm:                 a.Algorithm,
+		AlgorithmSigningComponent: a.AlgorithmSigningComponent,
+		Signature:                 a.Signature,
+		SignatureEncoding:         a.SignatureEncoding,
+		Indices:                   a.Indices,
+		Data:                      make([]string, 0, len(a.Data)),
+	}
+	for _, d := range a.Data {
+		r.Data = append(r.Data, hex.EncodeToString([]byte(d)))
+	}
+	if r.SignatureEncoding == "" {
+		r.SignatureEncoding = SignatureBinary
+	}
+	return r
+}
+
+// NewRecordsFromTx will create records for every AIP in every output of a BOB/BPU transaction
+func NewRecordsFromTx(tx *bpu.Tx) []*Record {
+	var records []*Record
+	// Outputs are in transaction order (BOB does not always set the index)
+	for vout, out := range tx.Out {
+		records = append(records, outputRecords(out.Tape, tx.Tx.H, uint32(vout))...)
+	}
+	return records
+}
+
+// NewRecordsFromTransaction will create records for every AIP in every output of a go-sdk transaction
+//
+// The outputs are split with TapesFromScript, the transaction is not parsed with BOB.
+func NewRecordsFromTransaction(tx *transaction.Transaction) ([]*Record, error) {
+	var records []*Record
+	txID := tx.TxID().String()
+	for vout, out := range tx.Outputs {
+		tapes, err := TapesFromScript(out.LockingScript)
+		if err != nil {
+			return nil, fmt.Errorf("output %d: %w", vout, err)
+		}
+		records = append(records, outputRecords(tapes, txID, uint32(vout))...)
+	}
+	return records, nil
+}
+
+// outputRecords creates the records of every AIP in the tapes of one output
+//
+// Each AIP is validated in strict mode, so the records hold the exact (untrimmed)
+// payload and agree with ValidateTapesWithOptions.
+func outputRecords(tapes []bpu.Tape, txID string, vout uint32) []*Record {
+	var records []*Record
+	instance := 0
+	for i, t := range tapes {
+		if findAipTape([]bpu.Tape{t}, 0) < 0 {
+			continue
+		}
+		result, _ := ValidateTapesWithOptions(tapes[:i+1], &ValidateOptions{Mode: ModeStrict, Instance: instance})
+		a := result.Aip
+		if !result.Valid {
+			a.Data, _ = a.dataFromTapes(tapes[:i+1], instance, specReconstruction)
+		}
+		r := newRecord(a, txID, vout, i)
+		r.Valid, r.Signer = result.Valid, result.Signer
+		r.Protocols = protocols(tapes, a.coveredCells(tapes[:i+1], instance))
+		instance++
+		records = append(records, r)
+	}
+	return records
+}
+
+// Aip returns the AIP stored in the record
+func (r *Record) Aip() (*Aip, error) {
+	a := &Aip{
+		Algorithm:                 r.Algorithm,
+		AlgorithmSigningComponent: r.AlgorithmSigningComponent,
+		Indices:                   r.Indices,
+		Signature:                 r.Signature,
+		SignatureEncoding:         r.SignatureEncoding,
+	}
+	for _, d := range r.Data {
+		b, err := hex.DecodeString(d)
+		if err != nil {
+			return nil, err
+		}
+		a.Data = append(a.Data, string(b))
+	}
+	return a, nil
+}
+
+// MarshalPushes returns the AIP as script pushdata (prefix, algorithm, component, signature, indices)
+//
+// The signature is pushed in its SignatureEncoding (raw bytes if empty), so an
+// AIP read with FromTape is re-emitted as it was found
+func (a *Aip) MarshalPushes() ([][]byte, error) {
+	sig, err := a.signaturePush(a.SignatureEncoding)
+	if err != nil {
+		return nil, err
+	}
+	pushes := [][]byte{
+		[]byte(Prefix),
+		[]byte(a.Algorithm),
+		[]byte(a.AlgorithmSigningComponent),
+		sig,
+	}
+	for _, index := range a.Indices {
+		pushes = append(pushes, []byte(strconv.Itoa(index)))
+	}
+	return pushes, nil
+}
+
+// MarshalTape returns the AIP as a BOB tape
+func (a *Aip) MarshalTape() (bpu.Tape, error) {
+	pushes, err := a.MarshalPushes()
+	if err != nil {
+		return bpu.Tape{}, err
+	}
+	tape := bpu.Tape{Cell: make([]bpu.Cell, 0, len(pushes))}
+	for i, p := range pushes {
+		s := string(p)
+		b := base64.StdEncoding.EncodeToString(p)
+		h := hex.EncodeToString(p)
+		tape.Cell = append(tape.Cell, bpu.Cell{S: &s, B: &b, H: &h, I: uint8(i)})
+	}
+	return tape, nil
+}
+
+// UnmarshalTape sets the AIP fields from a BOB tape
+//
+// Unlike FromTape, missing fields and invalid indices are returned as errors
+func (a *Aip) UnmarshalTape(tape bpu.Tape) error {
+	start := -1
+	for i, cell := range tape.Cell {
+		if cell.S != nil && *cell.S == Prefix {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return errors.New("no AIP prefix found")
+	} else if len(tape.Cell) < start+4 {
+		return errors.New("AIP tape is missing fields")
+	}
+
+	fields := tape.Cell[start+1 : start+4]
+	sig, encoding, ok := signatureFromCell(fields[2])
+	if fields[0].S == nil || fields[1].S == nil || !ok {
+		return errors.New("AIP tape has empty fields")
+	}
+	a.Algorithm = Algorithm(*fields[0].S)
+	a.AlgorithmSigningComponent = *fields[1].S
+	a.Signature, a.SignatureEncoding = sig, encoding
+
+	// Any remaining cells are indices
+	a.Indices = nil
+	for _, cell := range tape.Cell[start+4:] {
+		if cell.S == nil {
+			return errors.New("AIP index is empty")
+		}
+		index, err := strconv.Atoi(*cell.S)
+		if err != nil {
+			return err
+		}
+		a.Indices = append(a.Indices, index)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package aip
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bitcoinschema/go-bpu"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// TestAip_MarshalTape will test the methods MarshalTape() and UnmarshalTape()
+func TestAip_MarshalTape(t *testing.T) {
+	t.Parallel()
+
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	original := bobValidData.Out[0].Tape[2]
+
+	a := new(Aip)
+	if err = a.UnmarshalTape(original); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+
+	var tape bpu.Tape
+	if tape, err = a.MarshalTape(); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if len(tape.Cell) != len(original.Cell) {
+		t.Fatalf("%s Failed: expected [%d] cells but got [%d]", t.Name(), len(original.Cell), len(tape.Cell))
+	}
+	for i, cell := range tape.Cell {
+		if *cell.H != *original.Cell[i].H {
+			t.Errorf("%s Failed: cell [%d] expected [%s] but got [%s]", t.Name(), i, *original.Cell[i].H, *cell.H)
+		} else if *cell.B != *original.Cell[i].B {
+			t.Errorf("%s Failed: cell [%d] expected [%s] but got [%s]", t.Name(), i, *original.Cell[i].B, *cell.B)
+		}
+	}
+
+	// Round trip through FromTape
+	if b := NewFromTape(tape); b.Signature != a.Signature || b.AlgorithmSigningComponent != a.AlgorithmSigningComponent {
+		t.Errorf("%s Failed: expected [%v] but got [%v]", t.Name(), a, b)
+	}
+
+	// Indices round trip
+	a.Indices = []int{1, 2, 5}
+	if tape, err = a.MarshalTape(); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	b := new(Aip)
+	if err = b.UnmarshalTape(tape); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if fmt.Sprint(b.Indices) != fmt.Sprint(a.Indices) {
+		t.Errorf("%s Failed: expected [%v] but got [%v]", t.Name(), a.Indices, b.Indices)
+	}
+
+	// Invalid tapes and AIPs
+	if err = new(Aip).UnmarshalTape(bobValidData.Out[0].Tape[1]); err == nil {
+		t.Errorf("%s Failed: error was expected (no prefix)", t.Name())
+	}
+	short := bpu.Tape{Cell: original.Cell[:3]}
+	if err = new(Aip).UnmarshalTape(short); err == nil {
+		t.Errorf("%s Failed: error was expected (missing fields)", t.Name())
+	}
+	bad := "bad"
+	badIndex := bpu.Tape{Cell: append(append([]bpu.Cell{}, original.Cell...), bpu.Cell{S: &bad})}
+	if err = new(Aip).UnmarshalTape(badIndex); err == nil {
+		t.Errorf("%s Failed: error was expected (invalid index)", t.Name())
+	}
+	if _, err = (&Aip{Signature: "invalid-sig"}).MarshalTape(); err == nil {
+		t.Errorf("%s Failed: error was expected (invalid signature)", t.Name())
+	}
+}
+
+// TestNewRecordsFromTx will test the method NewRecordsFromTx()
+func TestNewRecordsFromTx(t *testing.T) {
+	t.Parallel()
+
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	records := NewRecordsFromTx(&bobValidData.Tx)
+	if len(records) != 1 {
+		t.Fatalf("%s Failed: expected [1] record but got [%d]", t.Name(), len(records))
+	}
+	r := records[0]
+	if r.TxID != "744a55a8637aa191aa058630da51803abbeadc2de3d65b4acace1f5f10789c5b" || r.Vout != 0 || r.TapeIndex != 2 {
+		t.Errorf("%s Failed: unexpected location [%s:%d:%d]", t.Name(), r.TxID, r.Vout, r.TapeIndex)
+	} else if !r.Valid {
+		t.Errorf("%s Failed: record should be valid", t.Name())
+	} else if r.Signer != "134a6TXxzgQ9Az3w8BcvgdZyA5UqRL89da" {
+		t.Errorf("%s Failed: expected signer [134a6TXxzgQ9Az3w8BcvgdZyA5UqRL89da] but got [%s]", t.Name(), r.Signer)
+	} else if len(r.Protocols) != 1 || r.Protocols[0] != "1BAPSuaPnfGnSBM3GLV9yhxUdYe4vGbdMT" {
+		t.Errorf("%s Failed: expected protocols [1BAPSuaPnfGnSBM3GLV9yhxUdYe4vGbdMT] but got %v", t.Name(), r.Protocols)
+	}
+
+	// JSON round trip
+	var raw []byte
+	if raw, err = json.Marshal(r); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	decoded := new(Record)
+	if err = json.Unmarshal(raw, decoded); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	var a *Aip
+	if a, err = decoded.Aip(); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if valid, err := a.Validate(); !valid {
+		t.Errorf("%s Failed: decoded AIP should be valid, error: %v", t.Name(), err)
+	}
+
+	// Re-emit the pushes and parse them back
+	var tape bpu.Tape
+	if tape, err = a.MarshalTape(); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	tapes := append(append([]bpu.Tape{}, bobValidData.Out[0].Tape[:2]...), tape)
+	if valid, err := ValidateTapes(tapes); !valid {
+		t.Errorf("%s Failed: re-emitted tapes should be valid, error: %v", t.Name(), err)
+	}
+
+	if _, err = (&Record{Data: []string{"zz"}}).Aip(); err == nil {
+		t.Errorf("%s Failed: error was expected (invalid hex)", t.Name())
+	}
+}
+
+// TestNewRecordsFromTransaction will test the method NewRecordsFromTransaction() returns the BOB records
+func TestNewRecordsFromTransaction(t *testing.T) {
+	t.Parallel()
+
+	for _, tx := range fixtureTxs(t) {
+		bobTx, err := bob.NewFromTx(tx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		var records []*Record
+		if records, err = NewRecordsFromTransaction(tx); err != nil {
+			t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+		} else if expected := NewRecordsFromTx(&bobTx.Tx); !reflect.DeepEqual(records, expected) {
+			t.Errorf("%s Failed: [%s] expected [%v] but got [%v]", t.Name(), tx.TxID(), expected, records)
+		}
+	}
+
+	// Invalid output scripts are errors
+	tx := transaction.NewTransaction()
+	tx.AddOutput(&transaction.TransactionOutput{LockingScript: script.NewFromBytes([]byte{script.OpFALSE, script.OpRETURN, 0x05, 'h'})})
+	if _, err := NewRecordsFromTransaction(tx); err == nil {
+		t.Errorf("%s Failed: error was expected (truncated push)", t.Name())
+	}
+}
+
+// TestNewRecordsFromTransaction_Whitespace will test records keep the exact payload of cells with whitespace
+func TestNewRecordsFromTransaction_Whitespace(t *testing.T) {
+	t.Parallel()
+
+	content := [][]byte{[]byte("19HxigV4QyBv3tHpQVcUEQyq1pzZVdoAut"), []byte("hello world\n"), []byte("text/plain")}
+	output, _, err := NewSignedOutput(examplePrivateKey, BitcoinECDSA, content)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	tx := transaction.NewTransaction()
+	tx.AddOutput(output)
+
+	var records []*Record
+	if records, err = NewRecordsFromTransaction(tx); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if len(records) != 1 || !records[0].Valid || records[0].Signer != exampleAddress {
+		t.Fatalf("%s Failed: expected a valid record signed by [%s] but got [%v]", t.Name(), exampleAddress, records)
+	}
+	if results, _ := ValidateTx(tx, ModeStrict); len(results) != 1 || results[0].Signer != records[0].Signer {
+		t.Errorf("%s Failed: expected the record to agree with ValidateTx but got [%v]", t.Name(), results)
+	}
+	if records[0].Data[2] != hex.EncodeToString(content[1]) {
+		t.Errorf("%s Failed: expected the untrimmed cell [%x] but got [%s]", t.Name(), content[1], records[0].Data[2])
+	}
+
+	// The record validates again after the JSON round trip
+	a, _ := records[0].Aip()
+	if valid, err := a.Validate(); !valid {
+		t.Errorf("%s Failed: record AIP should be valid, error: %v", t.Name(), err)
+	}
+
+	// An invalid signature has no signer
+	a.Data[2] = "hello world"
+	if r := NewRecord(a, "", 0, 0); r.Valid || r.Signer != "" {
+		t.Errorf("%s Failed: expected an invalid record without a signer but got [%t] [%s]", t.Name(), r.Valid, r.Signer)
+	}
+}
+
+// ExampleNewRecordsFromTx example using NewRecordsFromTx()
+func ExampleNewRecordsFromTx() {
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	for _, r := range NewRecordsFromTx(&bobValidData.Tx) {
+		fmt.Printf("%s:%d tape: %d signer: %s valid: %t", r.TxID, r.Vout, r.TapeIndex, r.Signer, r.Valid)
+	}
+	// Output:744a55a8637aa191aa058630da51803abbeadc2de3d65b4acace1f5f10789c5b:0 tape: 2 signer: 134a6TXxzgQ9Az3w8BcvgdZyA5UqRL89da valid: true
+}
+
+// BenchmarkAip_MarshalTape benchmarks the method MarshalTape()
+func BenchmarkAip_MarshalTape(b *testing.B) {
+	bobValidData, _ := bob.NewFromString(sampleValidBobTx)
+	a := NewFromTape(bobValidData.Out[0].Tape[2])
+	for i := 0; i < b.N; i++ {
+		_, _ = a.MarshalTape()
+	}
+}
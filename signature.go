@@ -0,0 +1,51 @@
+package aip
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/bitcoinschema/go-bpu"
+)
+
+// compactSignatureSize is the size of a raw compact signature
+const compactSignatureSize = 65
+
+// SignatureEncoding is how the signature is pushed in the AIP signature cell
+type SignatureEncoding string
+
+// Signature encodings found on-chain
+const (
+	SignatureBinary SignatureEncoding = "binary" // The raw 65 byte compact signature
+	SignatureBase64 SignatureEncoding = "base64" // The base64 text of the signature (SignOpReturnData)
+)
+
+// signatureFromCell returns the signature (base64) held by the cell and the form it was pushed in
+//
+// A push whose text is the base64 of a compact signature is the base64 form, any
+// other push is taken as the raw signature bytes (BOB's B field).
+func signatureFromCell(cell bpu.Cell) (string, SignatureEncoding, bool) {
+	if cell.S != nil {
+		if sig, err := base64.StdEncoding.DecodeString(*cell.S); err == nil && len(sig) == compactSignatureSize {
+			return *cell.S, SignatureBase64, true
+		}
+	}
+	if cell.B != nil {
+		return *cell.B, SignatureBinary, true
+	}
+	return "", "", false
+}
+
+// signaturePush returns the signature cell in the given encoding (binary if empty)
+func (a *Aip) signaturePush(encoding SignatureEncoding) ([]byte, error) {
+	sig, err := base64.StdEncoding.DecodeString(a.Signature)
+	if err != nil {
+		return nil, err
+	}
+	switch encoding {
+	case SignatureBinary, "":
+		return sig, nil
+	case SignatureBase64:
+		return []byte(a.Signature), nil
+	}
+	return nil, fmt.Errorf("unknown signature encoding: %s", encoding)
+}
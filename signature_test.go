@@ -0,0 +1,98 @@
+package aip
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bitcoinschema/go-bpu"
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// encodedTapes signs the data with the signature pushed in the encoding and parses it with BOB
+func encodedTapes(t testing.TB, encoding SignatureEncoding) ([][]byte, []bpu.Tape) {
+	outData, _, err := SignOpReturnDataWithOptions(examplePrivateKey, BitcoinSignedMessage,
+		[][]byte{[]byte("hello"), []byte(pipe)}, &SignOptions{Encoding: encoding})
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	s := &script.Script{}
+	_ = s.AppendOpcodes(script.OpFALSE, script.OpRETURN)
+	_ = s.AppendPushDataArray(outData)
+	return outData, parseScript(t, s)
+}
+
+// TestSignatureEncoding will test parsing, validating and re-emitting both signature encodings
+func TestSignatureEncoding(t *testing.T) {
+	t.Parallel()
+
+	for _, encoding := range []SignatureEncoding{SignatureBinary, SignatureBase64} {
+		outData, tapes := encodedTapes(t, encoding)
+		a := NewFromTape(tapes[len(tapes)-1])
+		if a.SignatureEncoding != encoding {
+			t.Errorf("%s Failed: [%s] expected the encoding to be found but got [%s]", t.Name(), encoding, a.SignatureEncoding)
+		}
+		if result, err := ValidateTapesWithOptions(tapes, nil); err != nil || !result.Valid {
+			t.Errorf("%s Failed: [%s] expected a valid signature but got [%v]", t.Name(), encoding, err)
+		}
+
+		// Re-emitted as found
+		pushes, err := a.MarshalPushes()
+		if err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), encoding, err.Error())
+		}
+		if !bytes.Equal(bytes.Join(pushes, nil), bytes.Join(outData[2:], nil)) {
+			t.Errorf("%s Failed: [%s] expected pushes [%x] but got [%x]", t.Name(), encoding, outData[2:], pushes)
+		}
+		var unmarshalled Aip
+		if err = unmarshalled.UnmarshalTape(tapes[len(tapes)-1]); err != nil || unmarshalled.SignatureEncoding != encoding {
+			t.Errorf("%s Failed: [%s] expected the encoding to be unmarshalled but got [%s]", t.Name(), encoding, unmarshalled.SignatureEncoding)
+		}
+	}
+
+	// The fixtures push raw signatures (S is not base64 text)
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	if a := NewFromTape(bobValidData.Out[0].Tape[2]); a.SignatureEncoding != SignatureBinary {
+		t.Errorf("%s Failed: expected [%s] but got [%s]", t.Name(), SignatureBinary, a.SignatureEncoding)
+	}
+
+	// The zero values: Sign pushes raw bytes, SignOpReturnData pushes base64
+	if signed, _ := Sign(examplePrivateKey, BitcoinSignedMessage, "hello"); signed.SignatureEncoding != SignatureBinary {
+		t.Errorf("%s Failed: expected [%s] but got [%s]", t.Name(), SignatureBinary, signed.SignatureEncoding)
+	}
+	if _, signed, _ := SignOpReturnDataWithOptions(examplePrivateKey, BitcoinSignedMessage, [][]byte{[]byte("hello")}, &SignOptions{}); signed.SignatureEncoding != SignatureBase64 {
+		t.Errorf("%s Failed: expected [%s] but got [%s]", t.Name(), SignatureBase64, signed.SignatureEncoding)
+	}
+	if r := NewRecord(&Aip{}, "", 0, 0); r.SignatureEncoding != SignatureBinary {
+		t.Errorf("%s Failed: expected [%s] but got [%s]", t.Name(), SignatureBinary, r.SignatureEncoding)
+	}
+
+	// Unknown encodings are not emitted
+	if _, _, err = SignOpReturnDataWithOptions(examplePrivateKey, BitcoinSignedMessage, [][]byte{[]byte("hello")}, &SignOptions{Encoding: "hex"}); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown encoding)", t.Name())
+	}
+}
+
+// ExampleSignOpReturnDataWithOptions example using SignOpReturnDataWithOptions()
+func ExampleSignOpReturnDataWithOptions() {
+	outData, a, err := SignOpReturnDataWithOptions(examplePrivateKey, BitcoinSignedMessage,
+		[][]byte{[]byte("hello"), []byte(pipe)}, &SignOptions{Encoding: SignatureBinary})
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("encoding: %s signature push: %d bytes", a.SignatureEncoding, len(outData[len(outData)-1]))
+	// Output:encoding: binary signature push: 65 bytes
+}
+
+// BenchmarkNewFromTape_Base64 benchmarks the method NewFromTape() with a base64 signature push
+func BenchmarkNewFromTape_Base64(b *testing.B) {
+	_, tapes := encodedTapes(b, SignatureBase64)
+	for i := 0; i < b.N; i++ {
+		_ = NewFromTape(tapes[len(tapes)-1])
+	}
+}
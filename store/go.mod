@@ -0,0 +1,26 @@
+module github.com/bitcoinschema/go-aip/store
+
+go 1.24.1
+
+require (
+	github.com/bitcoinschema/go-aip v0.0.0-20261018195243-19a9fa5df3ae
+	github.com/bitcoinschema/go-bob v0.5.2
+	github.com/bsv-blockchain/go-sdk v1.1.22
+	modernc.org/sqlite v1.40.1
+)
+
+require (
+	github.com/bitcoinschema/go-bpu v0.2.2 // indirect
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/ncruces/go-strftime v0.1.9 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	golang.org/x/crypto v0.47.0 // indirect
+	golang.org/x/exp v0.0.0-20250620022241-b7579e27df2b // indirect
+	golang.org/x/sys v0.40.0 // indirect
+	modernc.org/libc v1.66.10 // indirect
+	modernc.org/mathutil v1.7.1 // indirect
+	modernc.org/memory v1.11.0 // indirect
+)
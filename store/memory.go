@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/bitcoinschema/go-aip"
+)
+
+// Memory is an in-memory Store, safe for concurrent use
+type Memory struct {
+	mu      sync.RWMutex
+	records map[key]*aip.Record
+	closed  bool
+}
+
+// NewMemory will create a new in-memory store
+func NewMemory() *Memory {
+	return &Memory{records: make(map[key]*aip.Record)}
+}
+
+// Put implements Store
+func (m *Memory) Put(_ context.Context, records ...*aip.Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return errClosed
+	}
+	for _, r := range records {
+		if r == nil {
+			return errors.New("record is required")
+		}
+		c := *r
+		m.records[keyOf(r)] = &c
+	}
+	return nil
+}
+
+// BySigner implements Store
+func (m *Memory) BySigner(_ context.Context, signer string) ([]*aip.Record, error) {
+	return m.find(func(r *aip.Record) bool {
+		return r.Valid && r.Signer == signer
+	})
+}
+
+// ByTxID implements Store
+func (m *Memory) ByTxID(_ context.Context, txID string) ([]*aip.Record, error) {
+	return m.find(func(r *aip.Record) bool {
+		return r.TxID == txID
+	})
+}
+
+// ByProtocol implements Store
+func (m *Memory) ByProtocol(_ context.Context, protocol string) ([]*aip.Record, error) {
+	return m.find(func(r *aip.Record) bool {
+		return r.Valid && slices.Contains(r.Protocols, protocol)
+	})
+}
+
+// Close implements Store
+func (m *Memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	m.records = nil
+	return nil
+}
+
+// find returns copies of the records matching the filter
+func (m *Memory) find(match func(r *aip.Record) bool) ([]*aip.Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.closed {
+		return nil, errClosed
+	}
+	var found []*aip.Record
+	for _, r := range m.records {
+		if match(r) {
+			c := *r
+			found = append(found, &c)
+		}
+	}
+	sortRecords(found)
+	return found, nil
+}
+
+// errClosed is returned when using a closed store
+var errClosed = errors.New("store is closed")
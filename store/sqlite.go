@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/bitcoinschema/go-aip"
+	_ "modernc.org/sqlite" // Pure Go SQLite driver
+)
+
+// schema creates the tables used by the SQLite store
+const schema = `
+CREATE TABLE IF NOT EXISTS signatures (
+	txid       TEXT    NOT NULL,
+	vout       INTEGER NOT NULL,
+	tape_index INTEGER NOT NULL,
+	signer     TEXT    NOT NULL,
+	valid      INTEGER NOT NULL,
+	record     TEXT    NOT NULL,
+	PRIMARY KEY (txid, vout, tape_index)
+);
+CREATE INDEX IF NOT EXISTS signatures_signer ON signatures (signer, valid);
+CREATE TABLE IF NOT EXISTS protocols (
+	txid       TEXT    NOT NULL,
+	vout       INTEGER NOT NULL,
+	tape_index INTEGER NOT NULL,
+	protocol   TEXT    NOT NULL,
+	PRIMARY KEY (txid, vout, tape_index, protocol)
+);
+CREATE INDEX IF NOT EXISTS protocols_protocol ON protocols (protocol);
+`
+
+// SQLite is a Store backed by a SQLite database (pure Go, no cgo)
+type SQLite struct {
+	db *sql.DB
+}
+
+// NewSQLite will open (or create) the SQLite store at the path (":memory:" for a temporary store)
+func NewSQLite(ctx context.Context, path string) (*SQLite, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single connection keeps ":memory:" databases shared and serializes writes
+	db.SetMaxOpenConns(1)
+	if _, err = db.ExecContext(ctx, schema); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &SQLite{db: db}, nil
+}
+
+// Put implements Store
+func (s *SQLite) Put(ctx context.Context, records ...*aip.Record) (err error) {
+	var tx *sql.Tx
+	if tx, err = s.db.BeginTx(ctx, nil); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, r := range records {
+		if r == nil {
+			return errors.New("record is required")
+		}
+		var raw []byte
+		if raw, err = json.Marshal(r); err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx,
+			`INSERT OR REPLACE INTO signatures (txid, vout, tape_index, signer, valid, record) VALUES (?, ?, ?, ?, ?, ?)`,
+			r.TxID, r.Vout, r.TapeIndex, r.Signer, r.Valid, string(raw),
+		); err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx,
+			`DELETE FROM protocols WHERE txid = ? AND vout = ? AND tape_index = ?`, r.TxID, r.Vout, r.TapeIndex,
+		); err != nil {
+			return err
+		}
+		for _, protocol := range r.Protocols {
+			if _, err = tx.ExecContext(ctx,
+				`INSERT OR IGNORE INTO protocols (txid, vout, tape_index, protocol) VALUES (?, ?, ?, ?)`,
+				r.TxID, r.Vout, r.TapeIndex, protocol,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// BySigner implements Store
+func (s *SQLite) BySigner(ctx context.Context, signer string) ([]*aip.Record, error) {
+	return s.query(ctx,
+		`SELECT record FROM signatures WHERE signer = ? AND valid = 1 ORDER BY txid, vout, tape_index`, signer,
+	)
+}
+
+// ByTxID implements Store
+func (s *SQLite) ByTxID(ctx context.Context, txID string) ([]*aip.Record, error) {
+	return s.query(ctx,
+		`SELECT record FROM signatures WHERE txid = ? ORDER BY txid, vout, tape_index`, txID,
+	)
+}
+
+// ByProtocol implements Store
+func (s *SQLite) ByProtocol(ctx context.Context, protocol string) ([]*aip.Record, error) {
+	return s.query(ctx,
+		`SELECT s.record FROM signatures s
+		JOIN protocols p ON p.txid = s.txid AND p.vout = s.vout AND p.tape_index = s.tape_index
+		WHERE p.protocol = ? AND s.valid = 1 ORDER BY s.txid, s.vout, s.tape_index`, protocol,
+	)
+}
+
+// Close implements Store
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+// query returns the records selected by the query
+func (s *SQLite) query(ctx context.Context, query string, args ...any) ([]*aip.Record, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var records []*aip.Record
+	for rows.Next() {
+		var raw string
+		if err = rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		r := new(aip.Record)
+		if err = json.Unmarshal([]byte(raw), r); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
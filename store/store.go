@@ -0,0 +1,61 @@
+// Package store indexes AIP signatures so services can answer "what has address X signed?"
+//
+// Records come straight from the multi-AIP extraction (aip.NewRecordsFromTx) and
+// are keyed by their location (txid, vout, tape index), so putting the same
+// record twice replaces it. Two implementations are included: an in-memory store
+// and a SQLite store (pure Go, no cgo).
+package store
+
+import (
+	"context"
+	"sort"
+
+	"github.com/bitcoinschema/go-aip"
+)
+
+// Store is an index of AIP signatures
+//
+// BySigner and ByProtocol only return valid signatures (the signer of an
+// invalid signature is meaningless), ByTxID returns every stored record.
+// Results are ordered by txid, vout and tape index.
+type Store interface {
+	// Put stores the records (replacing records at the same location)
+	Put(ctx context.Context, records ...*aip.Record) error
+
+	// BySigner returns the valid signatures made by the address
+	BySigner(ctx context.Context, signer string) ([]*aip.Record, error)
+
+	// ByTxID returns every signature found in the transaction
+	ByTxID(ctx context.Context, txID string) ([]*aip.Record, error)
+
+	// ByProtocol returns the valid signatures covering the Bitcom protocol prefix
+	ByProtocol(ctx context.Context, protocol string) ([]*aip.Record, error)
+
+	// Close releases the resources used by the store
+	Close() error
+}
+
+// key is the location of a record
+type key struct {
+	txID      string
+	vout      uint32
+	tapeIndex int
+}
+
+// keyOf returns the location of the record
+func keyOf(r *aip.Record) key {
+	return key{txID: r.TxID, vout: r.Vout, tapeIndex: r.TapeIndex}
+}
+
+// sortRecords orders records by txid, vout and tape index
+func sortRecords(records []*aip.Record) {
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.TxID != b.TxID {
+			return a.TxID < b.TxID
+		} else if a.Vout != b.Vout {
+			return a.Vout < b.Vout
+		}
+		return a.TapeIndex < b.TapeIndex
+	})
+}
@@ -0,0 +1,199 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-aip"
+	"github.com/bitcoinschema/go-bob"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// Signers and protocols found in the fixture transactions
+const (
+	exampleSigner   = "1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK"
+	paymailSigner   = "1FiyJnrgwBc3Ff83V1yRWAkmXBdGrDQnXQ"
+	bProtocol       = "19HxigV4QyBv3tHpQVcUEQyq1pzZVdoAut"
+	mapProtocol     = "1PuQa7K62MiKCtssSLKy1kh56WWU7MtUR5"
+	multiSignedTxID = "6725b49d961adc4fab8ad211fb0c50a5e3b8a25c68a3f03b0e866776d197889d"
+	invalidTxID     = "308357a2dbc00a5d759bced087db83f11f49077c256af6191c8a34fa7044af12"
+)
+
+// testnetKey signs the testnet records
+var testnetKey, _ = ec.NewPrivateKey()
+
+// Both implementations satisfy the interface
+var (
+	_ Store = (*Memory)(nil)
+	_ Store = (*SQLite)(nil)
+)
+
+// loadRecords extracts every AIP record from the fixture transactions
+func loadRecords(t testing.TB) []*aip.Record {
+	raw, err := os.ReadFile("../testdata/txs.hex")
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var records []*aip.Record
+	for _, line := range strings.Split(string(raw), "\n") {
+		if line == "" {
+			continue
+		}
+		var bobTx *bob.Tx
+		if bobTx, err = bob.NewFromRawTxString(line); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		records = append(records, aip.NewRecordsFromTx(&bobTx.Tx)...)
+	}
+	return records
+}
+
+// newStores returns a fresh instance of every implementation
+func newStores(t *testing.T) map[string]Store {
+	sqlite, err := NewSQLite(context.Background(), ":memory:")
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return map[string]Store{"memory": NewMemory(), "sqlite": sqlite}
+}
+
+// TestStore will test every Store implementation
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	records := loadRecords(t)
+
+	for name, s := range newStores(t) {
+		if err := s.Put(ctx, records...); err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), name, err.Error())
+		}
+
+		// Putting the same records again replaces them
+		if err := s.Put(ctx, records...); err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), name, err.Error())
+		}
+
+		var (
+			// Testing private methods
+			tests = []struct {
+				query    string
+				run      func() ([]*aip.Record, error)
+				expected int
+			}{
+				{"by signer", func() ([]*aip.Record, error) { return s.BySigner(ctx, exampleSigner) }, 6},
+				{"by paymail signer", func() ([]*aip.Record, error) { return s.BySigner(ctx, paymailSigner) }, 1},
+				{"by unknown signer", func() ([]*aip.Record, error) { return s.BySigner(ctx, "1unknown") }, 0},
+				{"by txid", func() ([]*aip.Record, error) { return s.ByTxID(ctx, multiSignedTxID) }, 3},
+				{"by txid (invalid signature)", func() ([]*aip.Record, error) { return s.ByTxID(ctx, invalidTxID) }, 1},
+				{"by protocol", func() ([]*aip.Record, error) { return s.ByProtocol(ctx, bProtocol) }, 2},
+				{"by countersigned protocol", func() ([]*aip.Record, error) { return s.ByProtocol(ctx, aip.Prefix) }, 1},
+				{"by protocol (map)", func() ([]*aip.Record, error) { return s.ByProtocol(ctx, mapProtocol) }, 5},
+			}
+		)
+
+		for _, test := range tests {
+			found, err := test.run()
+			if err != nil {
+				t.Errorf("%s Failed: [%s] [%s] error not expected but got: %s", t.Name(), name, test.query, err.Error())
+			} else if len(found) != test.expected {
+				t.Errorf("%s Failed: [%s] [%s] expected [%d] records but got [%d]", t.Name(), name, test.query, test.expected, len(found))
+			}
+		}
+
+		// Records come back complete and in order
+		found, _ := s.ByTxID(ctx, multiSignedTxID)
+		if len(found) == 3 && (found[0].Vout != 0 || found[1].TapeIndex <= found[0].TapeIndex || found[2].Vout != 2) {
+			t.Errorf("%s Failed: [%s] unexpected order [%v] [%v] [%v]", t.Name(), name, found[0], found[1], found[2])
+		} else if a, err := found[0].Aip(); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), name, err.Error())
+		} else if valid, _ := a.Validate(); !valid {
+			t.Errorf("%s Failed: [%s] stored record should still validate", t.Name(), name)
+		}
+
+		// Testnet signers are indexed by their testnet address
+		if a, err := aip.SignWithNetwork(testnetKey, aip.BitcoinSignedMessage, "hello", aip.Testnet); err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), name, err.Error())
+		} else if err = s.Put(ctx, aip.NewRecord(a, "testnet", 0, 0)); err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), name, err.Error())
+		} else if found, _ = s.BySigner(ctx, a.AlgorithmSigningComponent); len(found) != 1 {
+			t.Errorf("%s Failed: [%s] expected [1] record for [%s] but got [%d]", t.Name(), name, a.AlgorithmSigningComponent, len(found))
+		}
+
+		if err := s.Put(ctx, nil); err == nil {
+			t.Errorf("%s Failed: [%s] error was expected (nil record)", t.Name(), name)
+		}
+		if err := s.Close(); err != nil {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), name, err.Error())
+		}
+		if _, err := s.BySigner(ctx, exampleSigner); err == nil {
+			t.Errorf("%s Failed: [%s] error was expected (closed store)", t.Name(), name)
+		}
+	}
+}
+
+// TestSQLite_Persistence will test the SQLite store keeps records on disk
+func TestSQLite_Persistence(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "aip.db")
+	s, err := NewSQLite(ctx, path)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	if err = s.Put(ctx, loadRecords(t)...); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	_ = s.Close()
+
+	if s, err = NewSQLite(ctx, path); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	defer func() {
+		_ = s.Close()
+	}()
+	if found, _ := s.BySigner(ctx, exampleSigner); len(found) != 6 {
+		t.Errorf("%s Failed: expected [6] records but got [%d]", t.Name(), len(found))
+	}
+
+	if _, err = NewSQLite(ctx, filepath.Join(t.TempDir(), "missing", "aip.db")); err == nil {
+		t.Errorf("%s Failed: error was expected (missing directory)", t.Name())
+	}
+}
+
+// ExampleNewMemory example using NewMemory()
+func ExampleNewMemory() {
+	bobTx, err := bob.NewFromRawTxString(strings.Split(string(mustReadFile("../testdata/txs.hex")), "\n")[0])
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	s := NewMemory()
+	ctx := context.Background()
+	_ = s.Put(ctx, aip.NewRecordsFromTx(&bobTx.Tx)...)
+	found, _ := s.BySigner(ctx, exampleSigner)
+	fmt.Printf("signed: %d txid: %s", len(found), found[0].TxID)
+	// Output:signed: 1 txid: 1f7341f10924d399c84160782e92e9ef3c8e544de865c54f974dd68d898171af
+}
+
+// mustReadFile reads a file or returns nothing
+func mustReadFile(name string) []byte {
+	b, _ := os.ReadFile(name)
+	return b
+}
+
+// BenchmarkSQLite_BySigner benchmarks the method BySigner()
+func BenchmarkSQLite_BySigner(b *testing.B) {
+	ctx := context.Background()
+	s, _ := NewSQLite(ctx, ":memory:")
+	_ = s.Put(ctx, loadRecords(b)...)
+	for i := 0; i < b.N; i++ {
+		_, _ = s.BySigner(ctx, exampleSigner)
+	}
+}
@@ -0,0 +1,239 @@
+package aip
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/bitcoinschema/go-bpu"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// blockHeaderSize is the size of a serialized block header
+const blockHeaderSize = 80
+
+// StreamResult is the validation of a single AIP, written as one NDJSON line
+type StreamResult struct {
+	TxID      string    `json:"txid"`              // Transaction ID
+	Vout      uint32    `json:"vout"`              // Output index
+	Signer    string    `json:"signer,omitempty"`  // Address recovered from the signature
+	Algorithm Algorithm `json:"algorithm"`         // Algorithm found in the AIP
+	Valid     bool      `json:"valid"`             // True if the signature is valid
+	Error     string    `json:"error,omitempty"`   // Why the signature is invalid
+	Variant   Variant   `json:"variant,omitempty"` // Payload variant that recovers the claimed signer (diagnosed failures)
+}
+
+// StreamOptions are the options used when validating a stream of transactions
+type StreamOptions struct {
+	Mode     Mode           // Validation mode (strict by default)
+	Workers  int            // Number of parallel validators (defaults to the number of CPUs)
+	Diagnose bool           // Search alternative payload reconstructions for failed signatures
+	Report   *QualityReport // Collects the data quality of every result (optional)
+}
+
+// check returns an error for options no transaction could be validated with
+func (o *StreamOptions) check() error {
+	if o == nil {
+		return nil
+	}
+	return o.Mode.check()
+}
+
+// ValidateTx extracts and validates every AIP across all outputs of a transaction
+func ValidateTx(tx *transaction.Transaction, mode Mode) ([]*StreamResult, error) {
+	if err := mode.check(); err != nil {
+		return nil, err
+	}
+	return validateTx(tx, &StreamOptions{Mode: mode})
+}
+
+// validateTx is ValidateTx with the stream options (diagnosing failures if asked)
+func validateTx(tx *transaction.Transaction, opts *StreamOptions) ([]*StreamResult, error) {
+	outputs := make([][]bpu.Tape, len(tx.Outputs))
+	for vout, out := range tx.Outputs {
+		tapes, err := TapesFromScript(out.LockingScript)
+		if err != nil {
+			return nil, err
+		}
+		outputs[vout] = tapes
+	}
+	txID := tx.TxID().String()
+
+	var results []*StreamResult
+	for vout, tapes := range outputs {
+		for instance := 0; findAipTape(tapes, instance) >= 0; instance++ {
+			r := &StreamResult{TxID: txID, Vout: uint32(vout)}
+			vOpts := &ValidateOptions{Mode: opts.Mode, Instance: instance}
+			result, vErr := ValidateTapesWithOptions(tapes, vOpts)
+			if result != nil {
+				r.Algorithm = result.Aip.Algorithm
+				r.Signer = result.Signer
+				r.Valid = result.Valid
+			}
+			if vErr != nil {
+				r.Error = vErr.Error()
+			}
+			if opts.Diagnose && !r.Valid {
+				if d := Diagnose(tapes, vOpts); d != nil {
+					r.Variant = d.Variant
+				}
+			}
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
+
+// ValidateBlock stream-parses a raw serialized block and writes an NDJSON result for every AIP
+//
+// Transactions are validated in parallel and results are written in block order.
+// It returns the number of transactions read.
+func ValidateBlock(r io.Reader, w io.Writer, opts *StreamOptions) (int, error) {
+	if err := opts.check(); err != nil {
+		return 0, err
+	}
+	br := bufio.NewReader(r)
+	if _, err := io.CopyN(io.Discard, br, blockHeaderSize); err != nil {
+		return 0, fmt.Errorf("failed to read block header: %w", err)
+	}
+	var count transaction.VarInt
+	if _, err := count.ReadFrom(br); err != nil {
+		return 0, fmt.Errorf("failed to read transaction count: %w", err)
+	}
+
+	read := uint64(0)
+	return validateStream(w, opts, func() (*transaction.Transaction, error) {
+		if read == uint64(count) {
+			return nil, io.EOF
+		}
+		tx := new(transaction.Transaction)
+		if _, err := tx.ReadFrom(br); err != nil {
+			return nil, fmt.Errorf("failed to read transaction %d: %w", read, err)
+		}
+		read++
+		return tx, nil
+	})
+}
+
+// ValidateRawTxs reads newline-delimited raw transaction hex and writes an NDJSON result for every AIP
+//
+// Empty lines are skipped. It returns the number of transactions read.
+func ValidateRawTxs(r io.Reader, w io.Writer, opts *StreamOptions) (int, error) {
+	if err := opts.check(); err != nil {
+		return 0, err
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	line := 0
+	return validateStream(w, opts, func() (*transaction.Transaction, error) {
+		for scanner.Scan() {
+			line++
+			raw := strings.TrimSpace(scanner.Text())
+			if raw == "" {
+				continue
+			}
+			tx, err := transaction.NewTransactionFromHex(raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse transaction on line %d: %w", line, err)
+			}
+			return tx, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	})
+}
+
+// validateStream validates the transactions returned by next in parallel and writes them in order
+func validateStream(w io.Writer, opts *StreamOptions, next func() (*transaction.Transaction, error)) (int, error) {
+	if opts == nil {
+		opts = &StreamOptions{}
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	type job struct {
+		seq int
+		tx  *transaction.Transaction
+	}
+	type done struct {
+		seq     int
+		results []*StreamResult
+		err     error
+	}
+	jobs := make(chan job, workers)
+	results := make(chan done, workers)
+
+	// Validators
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				r, err := validateTx(j.tx, opts)
+				results <- done{seq: j.seq, results: r, err: err}
+			}
+		}()
+	}
+
+	// Reader
+	var readErr error
+	go func() {
+		defer close(jobs)
+		for seq := 0; ; seq++ {
+			tx, err := next()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					readErr = err
+				}
+				return
+			}
+			jobs <- job{seq: seq, tx: tx}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Writer (in order)
+	encoder := json.NewEncoder(w)
+	pending := make(map[int]done)
+	written := 0
+	var err error
+	for d := range results {
+		pending[d.seq] = d
+		for p, ok := pending[written]; ok; p, ok = pending[written] {
+			delete(pending, written)
+			written++
+			if err != nil {
+				continue
+			}
+			if p.err != nil {
+				err = fmt.Errorf("failed to parse transaction %d: %w", p.seq, p.err)
+				continue
+			}
+			if opts.Report != nil {
+				opts.Report.Add(p.results...)
+			}
+			for _, r := range p.results {
+				if err = encoder.Encode(r); err != nil {
+					break
+				}
+			}
+		}
+	}
+	if readErr != nil {
+		return written, readErr
+	}
+	return written, err
+}
@@ -0,0 +1,107 @@
+package aip
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// loadTestFile reads a file from testdata or fails the test
+func loadTestFile(t testing.TB, name string) []byte {
+	b, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return b
+}
+
+// TestValidateBlock will test the method ValidateBlock()
+func TestValidateBlock(t *testing.T) {
+	t.Parallel()
+
+	block := loadTestFile(t, "block.bin")
+	expected := string(loadTestFile(t, "block.ndjson"))
+
+	for _, workers := range []int{0, 1, 4} {
+		var out bytes.Buffer
+		count, err := ValidateBlock(bytes.NewReader(block), &out, &StreamOptions{Workers: workers})
+		if err != nil {
+			t.Fatalf("%s Failed: [%d] error not expected but got: %s", t.Name(), workers, err.Error())
+		} else if count != 8 {
+			t.Errorf("%s Failed: [%d] expected [8] transactions but got [%d]", t.Name(), workers, count)
+		} else if out.String() != expected {
+			t.Errorf("%s Failed: [%d] expected [%s] but got [%s]", t.Name(), workers, expected, out.String())
+		}
+	}
+
+	// Truncated blocks
+	for _, size := range []int{40, 80, len(block) - 10} {
+		if _, err := ValidateBlock(bytes.NewReader(block[:size]), &bytes.Buffer{}, nil); err == nil {
+			t.Errorf("%s Failed: [%d] error was expected (truncated block)", t.Name(), size)
+		}
+	}
+}
+
+// TestValidateRawTxs will test the method ValidateRawTxs()
+func TestValidateRawTxs(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	count, err := ValidateRawTxs(bytes.NewReader(loadTestFile(t, "txs.hex")), &out, &StreamOptions{Workers: 2})
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if count != 7 {
+		t.Errorf("%s Failed: expected [7] transactions but got [%d]", t.Name(), count)
+	} else if expected := string(loadTestFile(t, "block.ndjson")); out.String() != expected {
+		t.Errorf("%s Failed: expected [%s] but got [%s]", t.Name(), expected, out.String())
+	}
+
+	if _, err = ValidateRawTxs(strings.NewReader("not hex\n"), &bytes.Buffer{}, nil); err == nil {
+		t.Errorf("%s Failed: error was expected (invalid line)", t.Name())
+	}
+	if count, err = ValidateRawTxs(strings.NewReader("\n\n"), &bytes.Buffer{}, nil); err != nil || count != 0 {
+		t.Errorf("%s Failed: expected no transactions but got [%d] [%v]", t.Name(), count, err)
+	}
+
+	// An unknown mode is rejected before anything is read or written
+	out.Reset()
+	if count, err = ValidateRawTxs(bytes.NewReader(loadTestFile(t, "txs.hex")), &out, &StreamOptions{Mode: "lenient"}); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown mode)", t.Name())
+	} else if count != 0 || out.Len() != 0 {
+		t.Errorf("%s Failed: expected no output but got [%d] [%s]", t.Name(), count, out.String())
+	}
+	if _, err = ValidateBlock(bytes.NewReader(loadTestFile(t, "block.bin")), &out, &StreamOptions{Mode: "lenient"}); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown mode)", t.Name())
+	}
+}
+
+// ExampleValidateTx example using ValidateTx()
+func ExampleValidateTx() {
+	b, err := os.ReadFile("testdata/txs.hex")
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	raw, _ := hex.DecodeString(strings.Split(string(b), "\n")[0])
+	var tx *transaction.Transaction
+	if tx, err = transaction.NewTransactionFromBytes(raw); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	results, _ := ValidateTx(tx, ModeStrict)
+	fmt.Printf("vout: %d valid: %t signer: %s", results[0].Vout, results[0].Valid, results[0].Signer)
+	// Output:vout: 0 valid: true signer: 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK
+}
+
+// BenchmarkValidateBlock benchmarks the method ValidateBlock()
+func BenchmarkValidateBlock(b *testing.B) {
+	block := loadTestFile(b, "block.bin")
+	for i := 0; i < b.N; i++ {
+		_, _ = ValidateBlock(bytes.NewReader(block), &bytes.Buffer{}, nil)
+	}
+}
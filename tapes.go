@@ -0,0 +1,73 @@
+package aip
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"unicode"
+
+	"github.com/bitcoinschema/go-bpu"
+	"github.com/bsv-blockchain/go-sdk/script"
+
+	"github.com/bitcoinschema/go-aip/bitcom"
+)
+
+// TapesFromScript splits a locking script into BOB tapes with the native Bitcom splitter
+//
+// The tapes are the ones BOB (NewFromTx) returns for the output, without parsing
+// the rest of the transaction.
+func TapesFromScript(s *script.Script) ([]bpu.Tape, error) {
+	segments, err := bitcom.Split(s)
+	if err != nil {
+		return nil, err
+	}
+	return TapesFromSegments(segments), nil
+}
+
+// TapesFromSegments converts Bitcom segments into BOB tapes (nil if there are none)
+func TapesFromSegments(segments []bitcom.Segment) []bpu.Tape {
+	if len(segments) == 0 {
+		return nil
+	}
+	tapes := make([]bpu.Tape, len(segments))
+	for i, segment := range segments {
+		tape := bpu.Tape{I: uint8(i), Cell: make([]bpu.Cell, len(segment.Cells))}
+
+		// BOB numbers the first tape 1 unless it starts with the OP_RETURN
+		if i == 0 && (len(segment.Cells) == 0 || segment.Cells[0].Op != script.OpRETURN) {
+			tape.I = 1
+		}
+		for j, c := range segment.Cells {
+
+			// BOB numbers cells from the last separator: an OP_RETURN right after a
+			// pipe joins this tape (dropped separators leave a gap) but is numbered 0
+			position := j
+			if j > 0 && segment.Cells[j-1].Index != c.Index-1 {
+				position = 0
+			}
+			tape.Cell[j] = tapeCell(c, position)
+		}
+		tapes[i] = tape
+	}
+	return tapes
+}
+
+// tapeCell converts a Bitcom cell into the cell BOB returns
+func tapeCell(c bitcom.Cell, position int) bpu.Cell {
+	cell := bpu.Cell{I: uint8(position), II: uint8(c.Index)}
+	if c.IsOpcode() {
+		if name, known := script.OpCodeValues[c.Op]; known {
+			op, ops := c.Op, name
+			cell.Op, cell.Ops = &op, &ops
+
+			// Non-printable opcodes carry no data fields
+			if !unicode.IsPrint(rune(op)) {
+				return cell
+			}
+		}
+	}
+	s := string(c.Data)
+	b := base64.StdEncoding.EncodeToString(c.Data)
+	h := hex.EncodeToString(c.Data)
+	cell.S, cell.B, cell.H = &s, &b, &h
+	return cell
+}
@@ -0,0 +1,103 @@
+package aip
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// fixtureTxs returns the fixture transactions (testdata/txs.hex)
+func fixtureTxs(t testing.TB) []*transaction.Transaction {
+	var txs []*transaction.Transaction
+	for _, line := range strings.Fields(string(loadTestFile(t, "txs.hex"))) {
+		tx, err := transaction.NewTransactionFromHex(line)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+// TestTapesFromScript will test the method TapesFromScript() returns the tapes BOB returns
+func TestTapesFromScript(t *testing.T) {
+	t.Parallel()
+
+	var scripts []*script.Script
+	for _, tx := range fixtureTxs(t) {
+		for _, out := range tx.Outputs {
+			scripts = append(scripts, out.LockingScript)
+		}
+	}
+	for _, f := range loadEnvelopeFixtures(t) {
+		tx, err := transaction.NewTransactionFromHex(f.Tx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		scripts = append(scripts, tx.Outputs[0].LockingScript)
+	}
+
+	// Separators and opcodes BOB handles in its own way
+	for _, asm := range []string{
+		"",
+		"OP_FALSE OP_RETURN 68656c6c6f 7c 7c 776f726c64 7c",
+		"7c 68656c6c6f OP_RETURN 68656c6c6f",
+		"OP_RETURN 68656c6c6f OP_SWAP 776f726c64",
+		"6a 68656c6c6f 7c 776f726c64",
+		"OP_FALSE OP_RETURN 68656c6c6f 7c OP_RETURN 776f726c64",
+		"OP_DUP OP_HASH160 0000000000000000000000000000000000000000 OP_EQUALVERIFY OP_CHECKSIG OP_RETURN OP_1 OP_16 OP_0 68656c6c6f",
+	} {
+		s, err := script.NewFromASM(asm)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		scripts = append(scripts, s)
+	}
+
+	for _, s := range scripts {
+		tapes, err := TapesFromScript(s)
+		if err != nil {
+			t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), s.String(), err.Error())
+		} else if expected := parseScript(t, s); !reflect.DeepEqual(tapes, expected) {
+			t.Errorf("%s Failed: [%s] expected [%v] but got [%v]", t.Name(), s.String(), expected, tapes)
+		}
+	}
+
+	// Truncated pushes are errors
+	if _, err := TapesFromScript(script.NewFromBytes([]byte{script.OpFALSE, script.OpRETURN, 0x05, 'h'})); err == nil {
+		t.Errorf("%s Failed: error was expected (truncated push)", t.Name())
+	}
+}
+
+// ExampleTapesFromScript example using TapesFromScript()
+func ExampleTapesFromScript() {
+	s, _ := script.NewFromHex("006a" + "22" + hex.EncodeToString([]byte(Prefix)) + "01" + hex.EncodeToString([]byte(pipe)) + "0568656c6c6f")
+	tapes, _ := TapesFromScript(s)
+	fmt.Printf("tapes: %d prefix: %s", len(tapes), *tapes[1].Cell[0].S)
+	// Output:tapes: 3 prefix: 15PciHG22SNLQJXMoSUaWVi7WSqc7hCfva
+}
+
+// BenchmarkTapesFromScript benchmarks the method TapesFromScript() on every output of a transaction
+func BenchmarkTapesFromScript(b *testing.B) {
+	tx := fixtureTxs(b)[0]
+	for i := 0; i < b.N; i++ {
+		for _, out := range tx.Outputs {
+			_, _ = TapesFromScript(out.LockingScript)
+		}
+	}
+}
+
+// BenchmarkTapesFromScript_Bob benchmarks the BOB path (NewFromTx, which also parses the inputs) for the same transaction
+func BenchmarkTapesFromScript_Bob(b *testing.B) {
+	tx := fixtureTxs(b)[0]
+	for i := 0; i < b.N; i++ {
+		bobTx, _ := bob.NewFromTx(tx)
+		_ = bobTx.Out[0].Tape
+	}
+}
@@ -0,0 +1,202 @@
+package aip
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitcoinschema/go-bpu"
+	bsm "github.com/bsv-blockchain/go-sdk/compat/bsm"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// TrustPolicy decides whether the signer of a valid signature is trusted
+//
+// Signers are addresses (any network), hex public keys or hex hash160s.
+// Protocol rules are keyed by the Bitcom prefix, optionally followed by a space
+// and the action (the cell after the prefix), e.g. "1BAPSuaPnfGnSBM3GLV9yhxUdYe4vGbdMT ATTEST".
+// An empty allow list trusts every signer that is not denied.
+type TrustPolicy struct {
+	Allow     []string            `json:"allow,omitempty" yaml:"allow,omitempty"`         // Trusted signers
+	Deny      []string            `json:"deny,omitempty" yaml:"deny,omitempty"`           // Never trusted (wins over every other rule)
+	Protocols map[string][]string `json:"protocols,omitempty" yaml:"protocols,omitempty"` // The only signers trusted for a protocol
+}
+
+// TrustReason explains a trust decision
+type TrustReason string
+
+// Trust decision reasons
+const (
+	TrustInvalidSignature TrustReason = "invalid_signature"    // The signature is not cryptographically valid
+	TrustDenied           TrustReason = "denied"               // The signer is in the deny list
+	TrustProtocolDenied   TrustReason = "protocol_not_trusted" // The signer is not trusted for a covered protocol
+	TrustProtocolAllowed  TrustReason = "protocol_trusted"     // The signer is trusted for every covered protocol rule
+	TrustAllowed          TrustReason = "allowed"              // The signer is in the allow list
+	TrustNotAllowed       TrustReason = "not_allowed"          // The signer is not in the allow list
+	TrustNoAllowList      TrustReason = "no_allow_list"        // No allow list, the signer is not denied
+)
+
+// TrustDecision is the outcome of evaluating a signature against a trust policy
+type TrustDecision struct {
+	Protocol   string            `json:"protocol,omitempty"` // The protocol rule behind the decision (if any)
+	Reason     TrustReason       `json:"reason"`             // Why the signer is (not) trusted
+	Signer     string            `json:"signer,omitempty"`   // The address recovered from the signature
+	Trusted    bool              `json:"trusted"`            // True if the signature is valid and the signer trusted
+	Valid      bool              `json:"valid"`              // The cryptographic validity of the signature
+	Validation *ValidationResult `json:"validation"`         // The validation result
+}
+
+// LoadTrustPolicy will load a trust policy from a JSON file (YAML is loaded by the trustyaml package)
+func LoadTrustPolicy(path string) (*TrustPolicy, error) {
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".json" {
+		return nil, fmt.Errorf("unknown trust policy format: %s", path)
+	}
+	raw, err := os.ReadFile(path) //nolint:gosec // The path is chosen by the caller
+	if err != nil {
+		return nil, err
+	}
+	p := new(TrustPolicy)
+	if err = json.Unmarshal(raw, p); err != nil {
+		return nil, err
+	}
+	if err = p.Check(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Check returns an error if an entry is not a signer (loaders call it after decoding)
+func (p *TrustPolicy) Check() error {
+	lists := map[string][]string{"allow": p.Allow, "deny": p.Deny}
+	for protocol, signers := range p.Protocols {
+		if len(protocol) == 0 {
+			return errors.New("protocol rule requires a prefix")
+		}
+		lists[protocol] = signers
+	}
+	for name, signers := range lists {
+		for _, signer := range signers {
+			if !isSigner(signer) {
+				return fmt.Errorf("invalid signer in %s: %s", name, signer)
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate validates the AIP in the tapes and decides whether its signer is trusted
+//
+// Rules apply in order: deny list, protocol rules (every covered protocol with a
+// rule must trust the signer), then the allow list.
+func (p *TrustPolicy) Evaluate(tapes []bpu.Tape, opts *ValidateOptions) (*TrustDecision, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &ValidateOptions{}
+	}
+
+	result, err := ValidateTapesWithOptions(tapes, opts)
+	decision := &TrustDecision{Reason: TrustInvalidSignature, Validation: result}
+	if result == nil || !result.Valid {
+		return decision, err
+	}
+	decision.Signer = result.Signer
+	decision.Valid = true
+
+	// Recover the key so every signer form can be matched
+	var pubKey *ec.PublicKey
+	var compressed bool
+	if pubKey, compressed, err = recoverKey(result.Aip); err != nil {
+		return decision, err
+	}
+	trusts := func(signers []string) bool {
+		for _, signer := range signers {
+			if matchSigner(signer, pubKey, compressed) == nil {
+				return true
+			}
+		}
+		return false
+	}
+
+	if trusts(p.Deny) {
+		decision.Reason = TrustDenied
+		return decision, nil
+	}
+
+	covered := false
+	for _, protocol := range protocolNames(tapes, result.Aip.coveredCells(tapes, opts.Instance)) {
+		signers, found := p.Protocols[protocol]
+		if !found {
+			continue
+		}
+		decision.Protocol = protocol
+		if !trusts(signers) {
+			decision.Reason = TrustProtocolDenied
+			return decision, nil
+		}
+		covered = true
+	}
+	if covered {
+		decision.Reason, decision.Trusted = TrustProtocolAllowed, true
+		return decision, nil
+	}
+	decision.Protocol = ""
+
+	switch {
+	case len(p.Allow) == 0:
+		decision.Reason, decision.Trusted = TrustNoAllowList, true
+	case trusts(p.Allow):
+		decision.Reason, decision.Trusted = TrustAllowed, true
+	default:
+		decision.Reason = TrustNotAllowed
+	}
+	return decision, nil
+}
+
+// isSigner returns true if the value is an address, hex public key or hex hash160
+func isSigner(value string) bool {
+	if _, _, err := parseAddress(value); err == nil {
+		return true
+	}
+	switch len(value) {
+	case 40:
+		_, err := hex.DecodeString(value)
+		return err == nil
+	case 66, 130:
+		_, err := ec.PublicKeyFromString(value)
+		return err == nil
+	}
+	return false
+}
+
+// recoverKey recovers the key that signed the AIP data
+func recoverKey(a *Aip) (*ec.PublicKey, bool, error) {
+	sig, err := base64.StdEncoding.DecodeString(a.Signature)
+	if err != nil {
+		return nil, false, err
+	}
+	return bsm.PubKeyFromSignature(sig, []byte(strings.Join(a.Data, "")))
+}
+
+// protocolNames returns each covered protocol as its prefix and as "prefix action"
+func protocolNames(tapes []bpu.Tape, cells []CellRef) []string {
+	var names []string
+	for _, ref := range protocolCells(tapes, cells) {
+		tape := tapes[ref.Tape]
+		if tape.Cell[ref.Cell].S == nil {
+			continue
+		}
+		prefix := *tape.Cell[ref.Cell].S
+		if ref.Cell+1 < len(tape.Cell) && tape.Cell[ref.Cell+1].S != nil {
+			names = append(names, prefix+" "+*tape.Cell[ref.Cell+1].S)
+		}
+		names = append(names, prefix)
+	}
+	return names
+}
@@ -0,0 +1,175 @@
+package aip
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitcoinschema/go-bpu"
+)
+
+// bapProtocol is the Bitcom prefix of the Bitcoin Attestation Protocol
+const bapProtocol = "1BAPSuaPnfGnSBM3GLV9yhxUdYe4vGbdMT"
+
+// attestTapes returns a BAP ATTEST signed by the key
+func attestTapes(t testing.TB, signer multiSigner) []bpu.Tape {
+	return parseScript(t, multiSignedScript(t, [][]byte{
+		[]byte(bapProtocol), []byte("ATTEST"), []byte("d4bc3f4a0b4e8a8b8d8e2e0d3d7c1a5e"), []byte("0"),
+	}, signer))
+}
+
+// TestTrustPolicy_Evaluate will test the method Evaluate()
+func TestTrustPolicy_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	hello := parseScript(t, multiSignedScript(t, [][]byte{[]byte("hello")}, multiSigner{examplePrivateKey, BitcoinSignedMessage}))
+	attest := attestTapes(t, multiSigner{examplePrivateKey, BitcoinSignedMessage})
+
+	// Signed by the example key but naming another address
+	a, err := Sign(examplePrivateKey, BitcoinSignedMessage, "hello"+pipe)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	a.AlgorithmSigningComponent = address(secondPrivateKey)
+	invalid := signedTapes(t, [][]byte{[]byte("hello"), []byte(pipe)}, a)
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name             string
+			policy           *TrustPolicy
+			tapes            []bpu.Tape
+			expectedTrusted  bool
+			expectedReason   TrustReason
+			expectedProtocol string
+		}{
+			{"empty policy", &TrustPolicy{}, hello, true, TrustNoAllowList, ""},
+			{"allowed", &TrustPolicy{Allow: []string{exampleAddress}}, hello, true, TrustAllowed, ""},
+			{"allowed by testnet address", &TrustPolicy{Allow: []string{exampleTestnetAddress}}, hello, true, TrustAllowed, ""},
+			{"not allowed", &TrustPolicy{Allow: []string{address(secondPrivateKey)}}, hello, false, TrustNotAllowed, ""},
+			{"denied by public key", &TrustPolicy{Allow: []string{exampleAddress}, Deny: []string{examplePubKey}}, hello, false, TrustDenied, ""},
+			{"invalid signature", &TrustPolicy{}, invalid, false, TrustInvalidSignature, ""},
+			{
+				"protocol action not trusted",
+				&TrustPolicy{Allow: []string{exampleAddress}, Protocols: map[string][]string{bapProtocol + " ATTEST": {address(secondPrivateKey)}}},
+				attest, false, TrustProtocolDenied, bapProtocol + " ATTEST",
+			},
+			{
+				"protocol action trusted",
+				&TrustPolicy{Protocols: map[string][]string{bapProtocol + " ATTEST": {exampleHash160}}},
+				attest, true, TrustProtocolAllowed, bapProtocol + " ATTEST",
+			},
+			{
+				"protocol trusted",
+				&TrustPolicy{Allow: []string{address(secondPrivateKey)}, Protocols: map[string][]string{bapProtocol: {exampleAddress}}},
+				attest, true, TrustProtocolAllowed, bapProtocol,
+			},
+			{
+				"protocol rule not covered",
+				&TrustPolicy{Allow: []string{exampleAddress}, Protocols: map[string][]string{bapProtocol: {address(secondPrivateKey)}}},
+				hello, true, TrustAllowed, "",
+			},
+			{
+				"deny wins over protocol",
+				&TrustPolicy{Deny: []string{exampleAddress}, Protocols: map[string][]string{bapProtocol: {exampleAddress}}},
+				attest, false, TrustDenied, "",
+			},
+		}
+	)
+
+	for _, test := range tests {
+		decision, err := test.policy.Evaluate(test.tapes, nil)
+		if decision == nil {
+			t.Errorf("%s Failed: [%s] expected a decision, error: %v", t.Name(), test.name, err)
+		} else if decision.Trusted != test.expectedTrusted || decision.Reason != test.expectedReason {
+			t.Errorf("%s Failed: [%s] expected [%t %s] but got [%t %s]", t.Name(), test.name, test.expectedTrusted, test.expectedReason, decision.Trusted, decision.Reason)
+		} else if decision.Protocol != test.expectedProtocol {
+			t.Errorf("%s Failed: [%s] expected protocol [%s] but got [%s]", t.Name(), test.name, test.expectedProtocol, decision.Protocol)
+		} else if decision.Valid != (test.expectedReason != TrustInvalidSignature) {
+			t.Errorf("%s Failed: [%s] unexpected validity [%t]", t.Name(), test.name, decision.Valid)
+		}
+	}
+
+	// Invalid policies are rejected
+	for _, p := range []*TrustPolicy{
+		{Allow: []string{"not-a-signer"}},
+		{Deny: []string{"02deadbeef"}},
+		{Protocols: map[string][]string{"": {exampleAddress}}},
+		{Protocols: map[string][]string{bapProtocol: {"bogus"}}},
+	} {
+		if _, err = p.Evaluate(hello, nil); err == nil {
+			t.Errorf("%s Failed: [%v] inputted and error was expected", t.Name(), p)
+		}
+	}
+}
+
+// TestLoadTrustPolicy will test the method LoadTrustPolicy()
+func TestLoadTrustPolicy(t *testing.T) {
+	t.Parallel()
+
+	attest := attestTapes(t, multiSigner{secondPrivateKey, BitcoinSignedMessage})
+	name := "trust.json"
+	p, err := LoadTrustPolicy(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("%s Failed: [%s] error not expected but got: %s", t.Name(), name, err.Error())
+	} else if len(p.Allow) != 1 || len(p.Deny) != 1 || len(p.Protocols[bapProtocol+" ATTEST"]) != 1 {
+		t.Errorf("%s Failed: [%s] unexpected policy [%v]", t.Name(), name, p)
+	}
+
+	var decision *TrustDecision
+	if decision, err = p.Evaluate(attest, nil); err != nil {
+		t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), name, err.Error())
+	} else if !decision.Trusted || decision.Reason != TrustProtocolAllowed {
+		t.Errorf("%s Failed: [%s] expected a trusted attestation but got [%s]", t.Name(), name, decision.Reason)
+	}
+
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"allow": ["not-a-signer"]}`), 0o600); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	malformed := filepath.Join(dir, "malformed.json")
+	if err := os.WriteFile(malformed, []byte("{"), 0o600); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	for _, path := range []string{invalid, malformed, filepath.Join("testdata", "trust.yaml"), filepath.Join("testdata", "txs.hex"), filepath.Join(dir, "missing.json")} {
+		if _, err := LoadTrustPolicy(path); err == nil {
+			t.Errorf("%s Failed: [%s] inputted and error was expected", t.Name(), path)
+		}
+	}
+}
+
+// ExampleTrustPolicy_Evaluate example using Evaluate()
+func ExampleTrustPolicy_Evaluate() {
+	s, err := newMultiSignedScript([][]byte{[]byte("hello world")},
+		multiSigner{examplePrivateKey, BitcoinSignedMessage},
+	)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	var tapes []bpu.Tape
+	if tapes, err = scriptTapes(s); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	p := &TrustPolicy{Allow: []string{address(examplePrivateKey)}}
+	var decision *TrustDecision
+	if decision, err = p.Evaluate(tapes, nil); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("valid: %t trusted: %t reason: %s", decision.Valid, decision.Trusted, decision.Reason)
+	// Output:valid: true trusted: true reason: allowed
+}
+
+// BenchmarkTrustPolicy_Evaluate benchmarks the method Evaluate()
+func BenchmarkTrustPolicy_Evaluate(b *testing.B) {
+	tapes := attestTapes(b, multiSigner{examplePrivateKey, BitcoinSignedMessage})
+	p := &TrustPolicy{Protocols: map[string][]string{bapProtocol + " ATTEST": {exampleAddress}}}
+	for i := 0; i < b.N; i++ {
+		_, _ = p.Evaluate(tapes, nil)
+	}
+}
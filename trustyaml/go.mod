@@ -0,0 +1,15 @@
+module github.com/bitcoinschema/go-aip/trustyaml
+
+go 1.24.1
+
+require (
+	github.com/bitcoinschema/go-aip v0.0.0-20261018195243-19a9fa5df3ae
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/bitcoinschema/go-bpu v0.2.2 // indirect
+	github.com/bsv-blockchain/go-sdk v1.1.22 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/crypto v0.47.0 // indirect
+)
@@ -0,0 +1,38 @@
+// Package trustyaml loads AIP signer trust policies from YAML files
+//
+// It is a separate module so the core package does not depend on a YAML parser.
+package trustyaml
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitcoinschema/go-aip"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadTrustPolicy will load a trust policy from a YAML (.yaml, .yml) file
+func LoadTrustPolicy(path string) (*aip.TrustPolicy, error) {
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".yaml" && ext != ".yml" {
+		return nil, fmt.Errorf("unknown trust policy format: %s", path)
+	}
+	raw, err := os.ReadFile(path) //nolint:gosec // The path is chosen by the caller
+	if err != nil {
+		return nil, err
+	}
+	return Unmarshal(raw)
+}
+
+// Unmarshal will decode and check a YAML trust policy
+func Unmarshal(raw []byte) (*aip.TrustPolicy, error) {
+	p := new(aip.TrustPolicy)
+	if err := yaml.Unmarshal(raw, p); err != nil {
+		return nil, err
+	}
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,53 @@
+package trustyaml
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/bitcoinschema/go-aip"
+)
+
+// TestLoadTrustPolicy will test the method LoadTrustPolicy()
+func TestLoadTrustPolicy(t *testing.T) {
+	t.Parallel()
+
+	p, err := LoadTrustPolicy(filepath.Join("..", "testdata", "trust.yaml"))
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	}
+	var expected *aip.TrustPolicy
+	if expected, err = aip.LoadTrustPolicy(filepath.Join("..", "testdata", "trust.json")); err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if !reflect.DeepEqual(p, expected) {
+		t.Errorf("%s Failed: expected [%v] but got [%v]", t.Name(), expected, p)
+	}
+
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.yml")
+	if err = os.WriteFile(invalid, []byte("allow:\n  - not-a-signer\n"), 0o600); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	malformed := filepath.Join(dir, "malformed.yaml")
+	if err = os.WriteFile(malformed, []byte("allow: [\n"), 0o600); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	for _, path := range []string{invalid, malformed, filepath.Join("..", "testdata", "trust.json"), filepath.Join(dir, "missing.yaml")} {
+		if _, err = LoadTrustPolicy(path); err == nil {
+			t.Errorf("%s Failed: [%s] inputted and error was expected", t.Name(), path)
+		}
+	}
+}
+
+// ExampleUnmarshal example using Unmarshal()
+func ExampleUnmarshal() {
+	p, err := Unmarshal([]byte("allow:\n  - 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK\n"))
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("allow: %v", p.Allow)
+	// Output:allow: [1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK]
+}
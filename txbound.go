@@ -0,0 +1,110 @@
+package aip
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bitcoinschema/go-bpu"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// BitcoinSignedMessageTxBound signs the data plus the outpoints spent by the transaction
+//
+// The signing component is the address (as with BitcoinSignedMessage). The signed
+// message is OP_RETURN + data + TxBinding(tx), so the data and signature cannot be
+// copied into another transaction. Validators that do not know this algorithm
+// reject it (the binding is missing from their payload).
+const BitcoinSignedMessageTxBound Algorithm = "BitcoinSignedMessageTxBound"
+
+// TxBinding returns the binding material of a transaction (hex)
+//
+// This is the double SHA-256 of every spent outpoint (txid + little endian vout)
+// in input order, the same commitment as hashPrevouts in the sighash preimage.
+func TxBinding(tx *transaction.Transaction) (string, error) {
+	if tx == nil || len(tx.Inputs) == 0 {
+		return "", errors.New("transaction has no inputs to bind to")
+	}
+	var buf bytes.Buffer
+	for _, input := range tx.Inputs {
+		if input.SourceTXID == nil {
+			return "", errors.New("input is missing the source txid")
+		}
+		buf.Write(input.SourceTXID.CloneBytes())
+		_ = binary.Write(&buf, binary.LittleEndian, input.SourceTxOutIndex)
+	}
+	return hex.EncodeToString(crypto.Sha256d(buf.Bytes())), nil
+}
+
+// SignTxBound signs the data bound to the inputs of the transaction
+//
+// The inputs must be added before signing (unlocking scripts are not needed).
+// The returned pushes are the data followed by the AIP fields (raw signature),
+// ready for NewEnvelopeScript. As with SignOpReturnData, end the data with a pipe
+// (pipes between protocols are not signed).
+func SignTxBound(privateKey *ec.PrivateKey, tx *transaction.Transaction, data [][]byte) ([][]byte, *Aip, error) {
+	binding, err := TxBinding(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var a *Aip
+	if a, err = Sign(privateKey, BitcoinSignedMessage, string(signedPayload(data))+binding); err != nil {
+		return nil, nil, err
+	}
+	a.Algorithm = BitcoinSignedMessageTxBound
+
+	var pushes [][]byte
+	if pushes, err = a.MarshalPushes(); err != nil {
+		return nil, nil, err
+	}
+	return append(append([][]byte{}, data...), pushes...), a, nil
+}
+
+// ValidateTxBound validates a transaction-bound AIP instance (0 = first) in the given output
+//
+// The payload is rebuilt from the output (spec interpretation) and the binding
+// from the transaction inputs. Data on the returned AIP ends with the binding.
+func ValidateTxBound(tx *transaction.Transaction, vout, instance int) (*ValidationResult, error) {
+	if tx == nil || vout < 0 || vout >= len(tx.Outputs) {
+		return nil, fmt.Errorf("output %d not found", vout)
+	}
+	binding, err := TxBinding(tx)
+	if err != nil {
+		return nil, err
+	}
+	var tapes []bpu.Tape
+	if tapes, err = TapesFromScript(tx.Outputs[vout].LockingScript); err != nil {
+		return nil, err
+	}
+	tapeIndex := findAipTape(tapes, instance)
+	if tapeIndex < 0 {
+		return nil, errors.New("no AIP tape found")
+	}
+
+	result := &ValidationResult{Aip: NewFromTape(tapes[tapeIndex]), Envelope: DetectEnvelope(tapes), Mode: ModeStrict}
+	a := result.Aip
+	if a.Algorithm != BitcoinSignedMessageTxBound {
+		return result, fmt.Errorf("algorithm is not %s: %s", BitcoinSignedMessageTxBound, a.Algorithm)
+	}
+	data, _ := a.dataFromTapes(tapes, instance, specReconstruction)
+	a.Data = append(data, binding)
+
+	var sig []byte
+	if sig, err = base64.StdEncoding.DecodeString(a.Signature); err != nil {
+		return result, err
+	}
+	if result.Signer, result.Compressed, err = verifySigner(
+		BitcoinSignedMessage, a.AlgorithmSigningComponent, sig, []byte(strings.Join(a.Data, "")),
+	); err != nil {
+		return result, err
+	}
+	result.Interpretation = InterpretationSpec
+	result.Valid = true
+	return result, nil
+}
@@ -0,0 +1,180 @@
+package aip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Source transactions spent by the bound transactions
+const (
+	exampleSourceTxID      = "f5a2ab3c3c7c3ef0b7ba2ba3a5b0dfbd8a51e4cb8a1b6c6a1a5b64f2b0ea3a41"
+	exampleOtherSourceTxID = "1c6c5e2a1e9b1e8e5a2f7c8f0e6c7c1d2e5f4b3a2c1d0e9f8a7b6c5d4e3f2a10"
+)
+
+// boundTx returns a transaction spending the given outpoint with a tx-bound AIP in output 0
+func boundTx(t testing.TB, sourceTxID string, vout uint32) *transaction.Transaction {
+	tx, err := newBoundTx(sourceTxID, vout)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return tx
+}
+
+// newBoundTx is boundTx returning the error (for examples)
+func newBoundTx(sourceTxID string, vout uint32) (*transaction.Transaction, error) {
+	txID, err := chainhash.NewHashFromHex(sourceTxID)
+	if err != nil {
+		return nil, err
+	}
+	tx := transaction.NewTransaction()
+	tx.AddInput(&transaction.TransactionInput{SourceTXID: txID, SourceTxOutIndex: vout, SequenceNumber: transaction.DefaultSequenceNumber})
+
+	var pushes [][]byte
+	if pushes, _, err = SignTxBound(examplePrivateKey, tx, [][]byte{[]byte("hello world"), []byte(pipe)}); err != nil {
+		return nil, err
+	}
+	var s *script.Script
+	if s, err = NewEnvelopeScript(EnvelopeOpFalseOpReturn, nil, pushes); err != nil {
+		return nil, err
+	}
+	tx.AddOutput(&transaction.TransactionOutput{LockingScript: s})
+	return tx, nil
+}
+
+// envelopeOutput returns an OP_FALSE OP_RETURN output or fails the test
+func envelopeOutput(t testing.TB, pushes [][]byte) *transaction.TransactionOutput {
+	s, err := NewEnvelopeScript(EnvelopeOpFalseOpReturn, nil, pushes)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return &transaction.TransactionOutput{LockingScript: s}
+}
+
+// TestTxBinding will test the method TxBinding()
+func TestTxBinding(t *testing.T) {
+	t.Parallel()
+
+	first, err := TxBinding(boundTx(t, exampleSourceTxID, 0))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	if other, _ := TxBinding(boundTx(t, exampleSourceTxID, 1)); other == first {
+		t.Errorf("%s Failed: binding should change with the vout", t.Name())
+	}
+	if other, _ := TxBinding(boundTx(t, exampleOtherSourceTxID, 0)); other == first {
+		t.Errorf("%s Failed: binding should change with the txid", t.Name())
+	}
+	if _, err = TxBinding(transaction.NewTransaction()); err == nil {
+		t.Errorf("%s Failed: error was expected (no inputs)", t.Name())
+	}
+	if _, _, err = SignTxBound(examplePrivateKey, transaction.NewTransaction(), nil); err == nil {
+		t.Errorf("%s Failed: error was expected (no inputs)", t.Name())
+	}
+}
+
+// TestValidateTxBound will test the method ValidateTxBound()
+func TestValidateTxBound(t *testing.T) {
+	t.Parallel()
+
+	tx := boundTx(t, exampleSourceTxID, 0)
+	result, err := ValidateTxBound(tx, 0, 0)
+	if err != nil {
+		t.Fatalf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if !result.Valid || result.Signer != "1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK" {
+		t.Errorf("%s Failed: expected a valid signature but got [%t] [%s]", t.Name(), result.Valid, result.Signer)
+	}
+
+	// The same AIP still validates through Validate() since the binding is in the data
+	if valid, _ := result.Aip.Validate(); !valid {
+		t.Errorf("%s Failed: expected Validate() to pass with the binding in the data", t.Name())
+	}
+
+	// Copied into a transaction spending other outpoints
+	copied := boundTx(t, exampleOtherSourceTxID, 0)
+	copied.Outputs[0] = tx.Outputs[0]
+	if _, err = ValidateTxBound(copied, 0, 0); err == nil {
+		t.Errorf("%s Failed: error was expected (copied signature)", t.Name())
+	}
+
+	// Legacy validators reject it rather than misinterpret it
+	if valid, _ := ValidateTapes(parseScript(t, tx.Outputs[0].LockingScript)); valid {
+		t.Errorf("%s Failed: ValidateTapes should not accept a tx-bound signature", t.Name())
+	}
+	if _, err = ValidateTapesWithOptions(parseScript(t, tx.Outputs[0].LockingScript), &ValidateOptions{Mode: ModeLegacy}); err == nil {
+		t.Errorf("%s Failed: error was expected (unknown algorithm)", t.Name())
+	}
+
+	// A regular AIP is not tx-bound
+	regular := boundTx(t, exampleSourceTxID, 0)
+	a, _ := Sign(examplePrivateKey, BitcoinECDSA, "hello world|")
+	pushes, _ := a.MarshalPushes()
+	regular.Outputs[0] = envelopeOutput(t, append([][]byte{[]byte("hello world"), []byte(pipe)}, pushes...))
+	if _, err = ValidateTxBound(regular, 0, 0); err == nil {
+		t.Errorf("%s Failed: error was expected (not tx-bound)", t.Name())
+	}
+	if _, err = ValidateTxBound(tx, 1, 0); err == nil {
+		t.Errorf("%s Failed: error was expected (missing output)", t.Name())
+	}
+
+	// A tx-bound countersignature is found by its instance
+	countersigned := boundTx(t, exampleSourceTxID, 0)
+	var bound [][]byte
+	if bound, _, err = SignTxBound(examplePrivateKey, countersigned, append(append([][]byte{[]byte("hello world"), []byte(pipe)}, pushes...), []byte(pipe))); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	countersigned.Outputs[0] = envelopeOutput(t, bound)
+	if result, err = ValidateTxBound(countersigned, 0, 1); err != nil {
+		t.Errorf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if !result.Valid {
+		t.Errorf("%s Failed: expected the second instance to be valid", t.Name())
+	}
+	if _, err = ValidateTxBound(countersigned, 0, 0); err == nil {
+		t.Errorf("%s Failed: error was expected (first instance is not tx-bound)", t.Name())
+	}
+	if _, err = ValidateTxBound(countersigned, 0, 2); err == nil {
+		t.Errorf("%s Failed: error was expected (missing instance)", t.Name())
+	}
+
+	// B and MAP segments, the pipe between them is not signed
+	segmented := boundTx(t, exampleSourceTxID, 0)
+	if bound, _, err = SignTxBound(examplePrivateKey, segmented, [][]byte{
+		[]byte("19HxigV4QyBv3tHpQVcUEQyq1pzZVdoAut"), []byte("hello world"), []byte("text/plain"), []byte(pipe),
+		[]byte("1PuQa7K62MiKCtssSLKy1kh56WWU7MtUR5"), []byte("SET"), []byte("app"), []byte("test"), []byte(pipe),
+	}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	segmented.Outputs[0] = envelopeOutput(t, bound)
+	if result, err = ValidateTxBound(segmented, 0, 0); err != nil {
+		t.Errorf("%s Failed: error not expected but got: %s", t.Name(), err.Error())
+	} else if !result.Valid {
+		t.Errorf("%s Failed: expected the B and MAP segments to be valid", t.Name())
+	}
+}
+
+// ExampleValidateTxBound example using ValidateTxBound()
+func ExampleValidateTxBound() {
+	tx, err := newBoundTx(exampleSourceTxID, 0)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	var result *ValidationResult
+	if result, err = ValidateTxBound(tx, 0, 0); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("valid: %t algorithm: %s signer: %s", result.Valid, result.Aip.Algorithm, result.Signer)
+	// Output:valid: true algorithm: BitcoinSignedMessageTxBound signer: 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK
+}
+
+// BenchmarkValidateTxBound benchmarks the method ValidateTxBound()
+func BenchmarkValidateTxBound(b *testing.B) {
+	tx := boundTx(b, exampleSourceTxID, 0)
+	for i := 0; i < b.N; i++ {
+		_, _ = ValidateTxBound(tx, 0, 0)
+	}
+}
@@ -0,0 +1,303 @@
+package aip
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bitcoinschema/go-bpu"
+	bsm "github.com/bsv-blockchain/go-sdk/compat/bsm"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// Mode is how strictly an AIP signature is validated
+type Mode string
+
+// Validation modes
+const (
+	ModeStrict Mode = "strict" // Spec only: known algorithm, exact payload, compressed key
+	ModeLegacy Mode = "legacy" // Also accepts known historical variants
+)
+
+// Interpretation is a known way of reconstructing the signed payload from tapes
+type Interpretation string
+
+// Known payload interpretations (in the order they are tried)
+const (
+	InterpretationSpec          Interpretation = "spec"            // OP_RETURN + exact cells + pipe separators
+	InterpretationTrimmed       Interpretation = "trimmed"         // Whitespace trimmed from each cell (SetDataFromTapes)
+	InterpretationNoPipe        Interpretation = "no_pipe"         // Exact cells without pipe separators
+	InterpretationTrimmedNoPipe Interpretation = "trimmed_no_pipe" // Whitespace trimmed without pipe separators
+	InterpretationEnvelope      Interpretation = "envelope"        // Indexed cells before the envelope start left out (no pipes for them)
+	InterpretationAllPipes      Interpretation = "all_pipes"       // Pipes between protocols signed too (SignOpReturnData before they were left out)
+)
+
+// interpretations maps each interpretation to its payload reconstruction
+var interpretations = []struct {
+	name           Interpretation
+	reconstruction reconstruction
+}{
+	{InterpretationSpec, specReconstruction},
+	{InterpretationTrimmed, reconstruction{trim: true, pipes: true}},
+	{InterpretationNoPipe, reconstruction{trim: false, pipes: false}},
+	{InterpretationTrimmedNoPipe, reconstruction{trim: true, pipes: false}},
+	{InterpretationEnvelope, reconstruction{trim: false, pipes: true, envelope: true}},
+	{InterpretationAllPipes, reconstruction{trim: false, pipes: true, allPipes: true}},
+}
+
+// ValidateOptions are the options used by ValidateTapesWithOptions
+type ValidateOptions struct {
+	Mode     Mode     // Strict (default) or legacy
+	Instance int      // Which AIP in the tapes to validate (0 = first)
+	Signer   string   // Expected signer: an address (any network), hex public key or hex hash160 (optional)
+	Observer Observer // Receives instrumentation (defaults to the one set with SetObserver)
+}
+
+// ValidationResult is the outcome of validating with options
+type ValidationResult struct {
+	Aip            *Aip           `json:"aip"`                      // The parsed AIP (Data holds the matched payload)
+	Compressed     bool           `json:"compressed"`               // Whether the signature referenced a compressed key
+	Envelope       Envelope       `json:"envelope,omitempty"`       // The script form carrying the signed data
+	Interpretation Interpretation `json:"interpretation,omitempty"` // The payload interpretation that matched
+	Mode           Mode           `json:"mode"`                     // The mode used to validate
+	Signer         string         `json:"signer,omitempty"`         // The address recovered from the signature
+	Valid          bool           `json:"valid"`                    // True if the signature is valid
+}
+
+// ValidateTapesWithOptions validates an AIP signature for a given []bob.Tape
+//
+// Strict mode only accepts the spec payload. Legacy mode tries each known
+// historical interpretation and reports which one matched.
+func ValidateTapesWithOptions(tapes []bpu.Tape, opts *ValidateOptions) (*ValidationResult, error) {
+	return ValidateTapesWithContext(context.Background(), tapes, opts)
+}
+
+// ValidateTapesWithContext is ValidateTapesWithOptions reporting its stages and outcome to the observer
+func ValidateTapesWithContext(ctx context.Context, tapes []bpu.Tape, opts *ValidateOptions) (*ValidationResult, error) {
+	if opts == nil {
+		opts = &ValidateOptions{}
+	}
+	o := opts.Observer
+	if o == nil {
+		o = currentObserver()
+	}
+	ctx, end := o.StartValidation(ctx)
+	start := time.Now()
+	event := ValidationEvent{Algorithm: "unknown", Mode: opts.Mode}
+	result, err := validateTapes(ctx, o, tapes, opts, &event)
+	event.Duration = time.Since(start)
+	event.Err = err
+	if result != nil {
+		event.Valid = result.Valid
+	}
+	end(event)
+	return result, err
+}
+
+// check returns an error if the mode is not known (empty means strict)
+func (m Mode) check() error {
+	if m != "" && m != ModeStrict && m != ModeLegacy {
+		return fmt.Errorf("unknown validation mode: %s", m)
+	}
+	return nil
+}
+
+// validateTapes validates the tapes and fills in the event
+func validateTapes(ctx context.Context, o Observer, tapes []bpu.Tape, opts *ValidateOptions,
+	event *ValidationEvent) (*ValidationResult, error) {
+	mode := opts.Mode
+	if mode == "" {
+		mode = ModeStrict
+	} else if err := mode.check(); err != nil {
+		event.Reason = FailureUnknownMode
+		return nil, err
+	}
+	event.Mode = mode
+
+	// Parse the AIP fields from the matching tape
+	var result *ValidationResult
+	var algorithm Algorithm
+	var sig []byte
+	if err := observeStage(ctx, o, StageParse, func() (err error) {
+		tapeIndex := findAipTape(tapes, opts.Instance)
+		if tapeIndex < 0 {
+			event.Reason = FailureNoAip
+			return errors.New("no AIP tape found")
+		}
+		result = &ValidationResult{Aip: NewFromTape(tapes[tapeIndex]), Envelope: DetectEnvelope(tapes), Mode: mode}
+		if algorithm, err = normalizeAlgorithm(result.Aip.Algorithm, mode); err != nil {
+			event.Reason = FailureUnknownAlgorithm
+			return err
+		}
+		event.Algorithm = algorithm
+		if sig, err = base64.StdEncoding.DecodeString(result.Aip.Signature); err != nil {
+			event.Reason = FailureMalformedSignature
+		}
+		return err
+	}); err != nil {
+		return result, err
+	}
+	a := result.Aip
+
+	// Try each interpretation allowed by the mode
+	var err error
+	event.Reason = FailureNoPayload
+	ao, _ := o.(attemptObserver)
+	for _, i := range interpretations {
+		if mode == ModeStrict && i.name != InterpretationSpec {
+			break
+		} else if i.reconstruction.envelope && len(a.Indices) == 0 {
+			continue // Same payload as the spec without indices
+		} else if i.reconstruction.allPipes && len(a.Indices) > 0 {
+			continue // Indices already turn every cell left out into a pipe
+		}
+		if ao != nil {
+			ao.attempt(i.name)
+		}
+		var data []string
+		if observeStage(ctx, o, StageReconstruct, func() error {
+			var found bool
+			if data, found = a.dataFromTapes(tapes, opts.Instance, i.reconstruction); !found {
+				return fmt.Errorf("no %s payload found", i.name)
+			}
+			if ao != nil {
+				ao.payload(data)
+			}
+			return nil
+		}) != nil {
+			continue
+		}
+		message := []byte(strings.Join(data, ""))
+		var pubKey *ec.PublicKey
+		var compressed bool
+		if err = observeStage(ctx, o, StageRecover, func() (err error) {
+			if pubKey, compressed, err = bsm.PubKeyFromSignature(sig, message); err == nil && ao != nil {
+				ao.recovered(pubKey, compressed)
+			}
+			return err
+		}); err != nil {
+			event.Reason = FailureRecover
+			continue
+		}
+		var signer string
+		if err = observeStage(ctx, o, StageVerify, func() (err error) {
+			if signer, err = verifyComponent(algorithm, a.AlgorithmSigningComponent, pubKey, compressed); err != nil {
+				return err
+			} else if mode == ModeStrict && !compressed {
+				event.Reason = FailureUncompressedKey
+				return errors.New("signature references an uncompressed key")
+			} else if len(opts.Signer) > 0 {
+				if err = matchSigner(opts.Signer, pubKey, compressed); err != nil {
+					event.Reason = FailureUnexpectedSigner
+				}
+			}
+			return err
+		}); err != nil {
+			if event.Reason == FailureUncompressedKey || event.Reason == FailureUnexpectedSigner {
+				break
+			}
+			event.Reason = FailureMismatch
+			continue
+		}
+		a.Data = data
+		result.Compressed = compressed
+		result.Interpretation = i.name
+		result.Signer = signer
+		result.Valid = true
+		event.Reason = FailureNone
+		return result, nil
+	}
+	if err == nil {
+		err = errors.New("no payload interpretation matched the signature")
+	}
+	return result, err
+}
+
+// normalizeAlgorithm returns the known algorithm, legacy mode ignores the case
+func normalizeAlgorithm(algorithm Algorithm, mode Mode) (Algorithm, error) {
+	for _, known := range []Algorithm{BitcoinECDSA, BitcoinSignedMessage, Paymail} {
+		if algorithm == known || (mode == ModeLegacy && strings.EqualFold(string(algorithm), string(known))) {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("unknown algorithm: %s", algorithm)
+}
+
+// verifySigner recovers the signer of the message and checks it against the signing component
+func verifySigner(algorithm Algorithm, component string, sig, message []byte) (string, bool, error) {
+	pubKey, wasCompressed, err := bsm.PubKeyFromSignature(sig, message)
+	if err != nil {
+		return "", false, err
+	}
+	var signer string
+	signer, err = verifyComponent(algorithm, component, pubKey, wasCompressed)
+	return signer, wasCompressed, err
+}
+
+// verifyComponent checks the recovered key against the signing component and returns the signer
+func verifyComponent(algorithm Algorithm, component string, pubKey *ec.PublicKey, wasCompressed bool) (string, error) {
+
+	// The paymail algorithm uses the identity key instead of the address
+	if algorithm == Paymail {
+		componentKey, err := ec.PublicKeyFromString(component)
+		if err != nil {
+			return "", err
+		}
+		if !componentKey.IsEqual(pubKey) {
+			return "", errors.New("signature does not match the identity key")
+		}
+		return Mainnet.address(pubKey, wasCompressed)
+	}
+
+	// The address may be on any network, the signer is reported on the same one
+	hash, network, err := parseAddress(component)
+	if err != nil {
+		return "", err
+	}
+	var signer string
+	if signer, err = network.address(pubKey, wasCompressed); err != nil {
+		return "", err
+	} else if !bytes.Equal(hash, pubKeyHash(pubKey, wasCompressed)) {
+		return "", fmt.Errorf("address (%s) does not match the recovered address (%s)", component, signer)
+	}
+	return signer, nil
+}
+
+// recoverAddress recovers the address (on the network) that signed the message
+func recoverAddress(sig, message []byte, network Network) (string, bool, error) {
+	pubKey, wasCompressed, err := bsm.PubKeyFromSignature(sig, message)
+	if err != nil {
+		return "", false, err
+	}
+	var signer string
+	if signer, err = network.address(pubKey, wasCompressed); err != nil {
+		return "", false, err
+	}
+	return signer, wasCompressed, nil
+}
+
+// findAipTape returns the index of the tape holding the given AIP instance (or -1)
+func findAipTape(tapes []bpu.Tape, instance int) int {
+	tapeIndex, _ := findAipCell(tapes, instance)
+	return tapeIndex
+}
+
+// findAipCell returns the position of the prefix of the given AIP instance (or -1, -1)
+func findAipCell(tapes []bpu.Tape, instance int) (int, int) {
+	count := 0
+	for i, tape := range tapes {
+		for j, cell := range tape.Cell {
+			if cell.S != nil && *cell.S == Prefix {
+				if count == instance {
+					return i, j
+				}
+				count++
+			}
+		}
+	}
+	return -1, -1
+}
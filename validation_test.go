@@ -0,0 +1,202 @@
+package aip
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bitcoinschema/go-bpu"
+	bsm "github.com/bsv-blockchain/go-sdk/compat/bsm"
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// legacyTapes signs the message (after OP_RETURN) and builds tapes from the given parts
+func legacyTapes(t *testing.T, parts []string, message string, algorithm Algorithm) []bpu.Tape {
+	a, err := Sign(examplePrivateKey, algorithm, message)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var data [][]byte
+	for _, part := range parts {
+		data = append(data, []byte(part))
+	}
+	return signedTapes(t, data, a)
+}
+
+// indexedTapes signs the message and builds tapes from the parts, signing only the given index
+//
+// Every cell left out of the indices (including the AIP fields) is a pipe in the message
+func indexedTapes(t *testing.T, parts []string, message string, index int) []bpu.Tape {
+	tapes := legacyTapes(t, parts, message, BitcoinECDSA)
+	last := &tapes[len(tapes)-1]
+	s := strconv.Itoa(index)
+	last.Cell = append(last.Cell, bpu.Cell{S: &s})
+	return tapes
+}
+
+// lowercaseTapes signs the spec payload and records the algorithm name in lower case
+func lowercaseTapes(t *testing.T) []bpu.Tape {
+	a, err := Sign(examplePrivateKey, BitcoinECDSA, "hello"+pipe)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	a.Algorithm = "bitcoin_ecdsa"
+	return signedTapes(t, [][]byte{[]byte("hello"), []byte(pipe)}, a)
+}
+
+// uncompressedTapes signs the spec payload referencing an uncompressed key
+func uncompressedTapes(t *testing.T) []bpu.Tape {
+	sig, err := bsm.SignMessageWithCompression(examplePrivateKey, []byte(opReturn+"hello"+pipe), false)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var addr *script.Address
+	if addr, err = script.NewAddressFromPublicKeyWithCompression(examplePrivateKey.PubKey(), true, false); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return signedTapes(t, [][]byte{[]byte("hello"), []byte(pipe)}, &Aip{
+		Algorithm:                 BitcoinECDSA,
+		AlgorithmSigningComponent: addr.AddressString,
+		Signature:                 base64.StdEncoding.EncodeToString(sig),
+	})
+}
+
+// TestValidateTapesWithOptions will test the method ValidateTapesWithOptions()
+func TestValidateTapesWithOptions(t *testing.T) {
+	t.Parallel()
+
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var bobInvalidData *bob.Tx
+	if bobInvalidData, err = bob.NewFromString(sampleInvalidBobTx); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	var (
+		// Testing private methods
+		tests = []struct {
+			name                   string
+			inputTapes             []bpu.Tape
+			inputMode              Mode
+			expectedValid          bool
+			expectedError          bool
+			expectedInterpretation Interpretation
+			expectedCompressed     bool
+		}{
+			{"valid strict", bobValidData.Out[0].Tape, ModeStrict, true, false, InterpretationSpec, true},
+			{"valid default mode", bobValidData.Out[0].Tape, "", true, false, InterpretationSpec, true},
+			{"valid legacy", bobValidData.Out[0].Tape, ModeLegacy, true, false, InterpretationSpec, true},
+			{"invalid address strict", bobInvalidData.Out[0].Tape, ModeStrict, false, true, "", false},
+			{"invalid address legacy", bobInvalidData.Out[0].Tape, ModeLegacy, false, true, "", false},
+			{
+				"trimmed strict",
+				legacyTapes(t, []string{" hello ", pipe}, "hello"+pipe, BitcoinECDSA),
+				ModeStrict, false, true, "", false,
+			},
+			{
+				"trimmed legacy",
+				legacyTapes(t, []string{" hello ", pipe}, "hello"+pipe, BitcoinECDSA),
+				ModeLegacy, true, false, InterpretationTrimmed, true,
+			},
+			{
+				"missing pipe strict",
+				legacyTapes(t, []string{"hello", pipe}, "hello", BitcoinSignedMessage),
+				ModeStrict, false, true, "", false,
+			},
+			{
+				"missing pipe legacy",
+				legacyTapes(t, []string{"hello", pipe}, "hello", BitcoinSignedMessage),
+				ModeLegacy, true, false, InterpretationNoPipe, true,
+			},
+			{
+				"trimmed and missing pipe legacy",
+				legacyTapes(t, []string{"hello\n", pipe}, "hello", Paymail),
+				ModeLegacy, true, false, InterpretationTrimmedNoPipe, true,
+			},
+			{
+				"untrimmed whitespace strict",
+				legacyTapes(t, []string{" hello ", pipe}, " hello "+pipe, BitcoinECDSA),
+				ModeStrict, true, false, InterpretationSpec, true,
+			},
+			{
+				"indexed strict",
+				indexedTapes(t, []string{"a", "b", pipe}, strings.Repeat(pipe, 3)+"b"+strings.Repeat(pipe, 5), 3),
+				ModeStrict, true, false, InterpretationSpec, true,
+			},
+			{
+				"indexed envelope strict",
+				indexedTapes(t, []string{"a", "b", pipe}, pipe+"b"+strings.Repeat(pipe, 5), 3),
+				ModeStrict, false, true, "", false,
+			},
+			{
+				"indexed envelope legacy",
+				indexedTapes(t, []string{"a", "b", pipe}, pipe+"b"+strings.Repeat(pipe, 5), 3),
+				ModeLegacy, true, false, InterpretationEnvelope, true,
+			},
+			{
+				"all pipes strict",
+				legacyTapes(t, []string{"hello", pipe, "SET", pipe}, "hello"+pipe+"SET"+pipe, BitcoinECDSA),
+				ModeStrict, false, true, "", false,
+			},
+			{
+				"all pipes legacy",
+				legacyTapes(t, []string{"hello", pipe, "SET", pipe}, "hello"+pipe+"SET"+pipe, BitcoinECDSA),
+				ModeLegacy, true, false, InterpretationAllPipes, true,
+			},
+			{"algorithm case strict", lowercaseTapes(t), ModeStrict, false, true, "", false},
+			{"algorithm case legacy", lowercaseTapes(t), ModeLegacy, true, false, InterpretationSpec, true},
+			{"uncompressed strict", uncompressedTapes(t), ModeStrict, false, true, "", false},
+			{"uncompressed legacy", uncompressedTapes(t), ModeLegacy, true, false, InterpretationSpec, false},
+			{"unknown mode", bobValidData.Out[0].Tape, "loose", false, true, "", false},
+			{"no aip", []bpu.Tape{*new(bpu.Tape)}, ModeLegacy, false, true, "", false},
+		}
+	)
+
+	// Run tests
+	for _, test := range tests {
+		result, err := ValidateTapesWithOptions(test.inputTapes, &ValidateOptions{Mode: test.inputMode})
+		if err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] error was expected", t.Name(), test.name)
+		} else if result == nil {
+			continue
+		} else if result.Valid != test.expectedValid {
+			t.Errorf("%s Failed: [%s] expected valid [%t] but got [%t]", t.Name(), test.name, test.expectedValid, result.Valid)
+		} else if result.Interpretation != test.expectedInterpretation {
+			t.Errorf("%s Failed: [%s] expected [%s] but got [%s]", t.Name(), test.name, test.expectedInterpretation, result.Interpretation)
+		} else if result.Compressed != test.expectedCompressed {
+			t.Errorf("%s Failed: [%s] expected compressed [%t] but got [%t]", t.Name(), test.name, test.expectedCompressed, result.Compressed)
+		}
+	}
+}
+
+// ExampleValidateTapesWithOptions example using ValidateTapesWithOptions()
+func ExampleValidateTapesWithOptions() {
+	bobValidData, err := bob.NewFromString(sampleValidBobTx)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	var result *ValidationResult
+	if result, err = ValidateTapesWithOptions(bobValidData.Out[0].Tape, &ValidateOptions{Mode: ModeLegacy}); err != nil {
+		fmt.Printf("AIP is invalid: %s", err.Error())
+		return
+	}
+	fmt.Printf("valid: %t interpretation: %s signer: %s", result.Valid, result.Interpretation, result.Signer)
+	// Output:valid: true interpretation: spec signer: 134a6TXxzgQ9Az3w8BcvgdZyA5UqRL89da
+}
+
+// BenchmarkValidateTapesWithOptions benchmarks the method ValidateTapesWithOptions()
+func BenchmarkValidateTapesWithOptions(b *testing.B) {
+	bobValidData, _ := bob.NewFromString(sampleValidBobTx)
+	for i := 0; i < b.N; i++ {
+		_, _ = ValidateTapesWithOptions(bobValidData.Out[0].Tape, &ValidateOptions{Mode: ModeLegacy})
+	}
+}
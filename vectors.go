@@ -0,0 +1,98 @@
+package aip
+
+import (
+	_ "embed" // Used to embed the published vector corpus
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// vectorsJSON is the published corpus (see testdata/vectors.json)
+//
+//go:embed testdata/vectors.json
+var vectorsJSON []byte
+
+// Vector is a single deterministic AIP signing vector
+//
+// All binary fields are hex encoded so the corpus can be shared between
+// implementations (JS, Python, Go) without any ambiguity about string encodings
+type Vector struct {
+	Description      string    `json:"description"`       // Human readable description of the case
+	PrivateKey       string    `json:"private_key"`       // Hex private key used to sign
+	Algorithm        Algorithm `json:"algorithm"`         // AIP algorithm used to sign
+	Data             []string  `json:"data"`              // Hex data parts (pushes before the AIP prefix)
+	Message          string    `json:"message"`           // Hex of the exact bytes signed (OP_RETURN + data, without the pipes between protocols)
+	SigningComponent string    `json:"signing_component"` // Expected address or identity key
+	Signature        string    `json:"signature"`         // Expected base64 signature
+	Script           string    `json:"script"`            // Expected OP_FALSE OP_RETURN script hex
+}
+
+// Key returns the private key for the vector
+func (v *Vector) Key() (*ec.PrivateKey, error) {
+	privBytes, err := hex.DecodeString(v.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, _ := ec.PrivateKeyFromBytes(privBytes)
+	return privateKey, nil
+}
+
+// DataParts returns the decoded data parts for the vector
+func (v *Vector) DataParts() ([][]byte, error) {
+	parts := make([][]byte, 0, len(v.Data))
+	for _, d := range v.Data {
+		part, err := hex.DecodeString(d)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// MessageBytes returns the decoded message that is expected to be signed
+func (v *Vector) MessageBytes() ([]byte, error) {
+	return hex.DecodeString(v.Message)
+}
+
+// ScriptBytes returns the decoded script that is expected to be emitted
+func (v *Vector) ScriptBytes() ([]byte, error) {
+	return hex.DecodeString(v.Script)
+}
+
+// LoadVectors will decode a vector corpus from the given reader
+func LoadVectors(r io.Reader) ([]*Vector, error) {
+	var vectors []*Vector
+	if err := json.NewDecoder(r).Decode(&vectors); err != nil {
+		return nil, err
+	}
+	if len(vectors) == 0 {
+		return nil, errors.New("no vectors found")
+	}
+	return vectors, nil
+}
+
+// LoadVectorsFile will decode a vector corpus from the given file path
+func LoadVectorsFile(path string) ([]*Vector, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return LoadVectors(f)
+}
+
+// Vectors returns the vector corpus published with this package
+func Vectors() ([]*Vector, error) {
+	var vectors []*Vector
+	if err := json.Unmarshal(vectorsJSON, &vectors); err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
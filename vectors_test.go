@@ -0,0 +1,251 @@
+package aip
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-bob"
+	"github.com/bitcoinschema/go-bpu"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// loadTestVectors loads the corpus or fails the test
+func loadTestVectors(t *testing.T) []*Vector {
+	vectors, err := LoadVectorsFile("testdata/vectors.json")
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return vectors
+}
+
+// vectorTapes builds the vector script and parses it with BOB
+func vectorTapes(t *testing.T, v *Vector) []bpu.Tape {
+	parts, err := v.DataParts()
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return signedTapes(t, parts, &Aip{
+		Algorithm:                 v.Algorithm,
+		AlgorithmSigningComponent: v.SigningComponent,
+		Signature:                 v.Signature,
+	})
+}
+
+// signedTapes builds an OP_FALSE OP_RETURN script of the data parts followed by the AIP fields
+// and parses it with BOB
+//
+// BOB exposes the signature via the base64 cell, so the on-chain form carries the raw bytes
+func signedTapes(t *testing.T, parts [][]byte, a *Aip) []bpu.Tape {
+	sig, err := base64.StdEncoding.DecodeString(a.Signature)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	s := &script.Script{}
+	_ = s.AppendOpcodes(script.OpFALSE, script.OpRETURN)
+	_ = s.AppendPushDataArray(append(append([][]byte{}, parts...),
+		[]byte(Prefix),
+		[]byte(a.Algorithm),
+		[]byte(a.AlgorithmSigningComponent),
+		sig,
+	))
+	tx := transaction.NewTransaction()
+	tx.AddOutput(&transaction.TransactionOutput{LockingScript: s})
+
+	var bobTx *bob.Tx
+	if bobTx, err = bob.NewFromRawTxString(hex.EncodeToString(tx.Bytes())); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return bobTx.Out[0].Tape
+}
+
+// isPiped returns true if the vector separates its data from AIP with a pipe (required by the BOB path)
+func isPiped(v *Vector) bool {
+	return len(v.Data) > 0 && v.Data[len(v.Data)-1] == hex.EncodeToString([]byte(pipe))
+}
+
+// TestVectors will test the embedded corpus matches the testdata file
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	embedded, err := Vectors()
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	vectors := loadTestVectors(t)
+	if len(embedded) != len(vectors) {
+		t.Fatalf("%s Failed: expected [%d] vectors but got [%d]", t.Name(), len(vectors), len(embedded))
+	}
+
+	if _, err = LoadVectors(strings.NewReader("[]")); err == nil {
+		t.Errorf("%s Failed: error was expected for an empty corpus", t.Name())
+	}
+	if _, err = LoadVectorsFile("testdata/missing.json"); err == nil {
+		t.Errorf("%s Failed: error was expected for a missing file", t.Name())
+	}
+}
+
+// TestVectors_Sign will test Sign() against the corpus
+func TestVectors_Sign(t *testing.T) {
+	t.Parallel()
+
+	for idx, v := range loadTestVectors(t) {
+		priv, err := v.Key()
+		if err != nil {
+			t.Fatalf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+		}
+		var parts [][]byte
+		if parts, err = v.DataParts(); err != nil {
+			t.Fatalf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+		}
+		var message []byte
+		if message, err = v.MessageBytes(); err != nil {
+			t.Fatalf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+		}
+
+		// The pipes between protocols split the tapes and are not signed
+		var a *Aip
+		if a, err = Sign(priv, v.Algorithm, string(signedPayload(parts))); err != nil {
+			t.Errorf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+		} else if a.Signature != v.Signature {
+			t.Errorf("%d %s Failed: [%s] expected signature [%s] but got [%s]", idx, t.Name(), v.Description, v.Signature, a.Signature)
+		} else if a.AlgorithmSigningComponent != v.SigningComponent {
+			t.Errorf("%d %s Failed: [%s] expected component [%s] but got [%s]", idx, t.Name(), v.Description, v.SigningComponent, a.AlgorithmSigningComponent)
+		} else if strings.Join(a.Data, "") != string(message) {
+			t.Errorf("%d %s Failed: [%s] expected message [%x] but got [%x]", idx, t.Name(), v.Description, message, strings.Join(a.Data, ""))
+		}
+	}
+}
+
+// TestVectors_SignOpReturnData will test SignOpReturnData() against the corpus
+func TestVectors_SignOpReturnData(t *testing.T) {
+	t.Parallel()
+
+	for idx, v := range loadTestVectors(t) {
+		priv, err := v.Key()
+		if err != nil {
+			t.Fatalf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+		}
+		var parts [][]byte
+		if parts, err = v.DataParts(); err != nil {
+			t.Fatalf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+		}
+
+		var outData [][]byte
+		var a *Aip
+		if outData, a, err = SignOpReturnData(priv, v.Algorithm, parts); err != nil {
+			t.Errorf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+			continue
+		} else if a.Signature != v.Signature {
+			t.Errorf("%d %s Failed: [%s] expected signature [%s] but got [%s]", idx, t.Name(), v.Description, v.Signature, a.Signature)
+		}
+
+		s := &script.Script{}
+		_ = s.AppendOpcodes(script.OpFALSE, script.OpRETURN)
+		_ = s.AppendPushDataArray(outData)
+		if s.String() != v.Script {
+			t.Errorf("%d %s Failed: [%s] expected script [%s] but got [%s]", idx, t.Name(), v.Description, v.Script, s.String())
+		}
+	}
+}
+
+// TestVectors_Validate will test Validate() against the corpus
+func TestVectors_Validate(t *testing.T) {
+	t.Parallel()
+
+	for idx, v := range loadTestVectors(t) {
+		message, err := v.MessageBytes()
+		if err != nil {
+			t.Fatalf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+		}
+		a := &Aip{
+			Algorithm:                 v.Algorithm,
+			AlgorithmSigningComponent: v.SigningComponent,
+			Data:                      []string{string(message[:1]), string(message[1:])},
+			Signature:                 v.Signature,
+		}
+		if valid, err := a.Validate(); !valid {
+			t.Errorf("%d %s Failed: [%s] validation should have passed, error: %v", idx, t.Name(), v.Description, err)
+		}
+	}
+}
+
+// TestVectors_Tapes will test the BOB parsing and validation paths against the corpus
+func TestVectors_Tapes(t *testing.T) {
+	t.Parallel()
+
+	for idx, v := range loadTestVectors(t) {
+		if !isPiped(v) {
+			continue
+		}
+		message, err := v.MessageBytes()
+		if err != nil {
+			t.Fatalf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+		}
+		tapes := vectorTapes(t, v)
+
+		if a := NewFromTapes(tapes); a == nil {
+			t.Errorf("%d %s Failed: [%s] nil was not expected", idx, t.Name(), v.Description)
+		} else if a.Algorithm != v.Algorithm {
+			t.Errorf("%d %s Failed: [%s] expected [%s] but got [%s]", idx, t.Name(), v.Description, v.Algorithm, a.Algorithm)
+		} else if a.AlgorithmSigningComponent != v.SigningComponent {
+			t.Errorf("%d %s Failed: [%s] expected [%s] but got [%s]", idx, t.Name(), v.Description, v.SigningComponent, a.AlgorithmSigningComponent)
+		} else if strings.Join(a.Data, "") != string(message) {
+			t.Errorf("%d %s Failed: [%s] expected message [%x] but got [%x]", idx, t.Name(), v.Description, message, strings.Join(a.Data, ""))
+		}
+
+		if valid, err := ValidateTapes(tapes); !valid {
+			t.Errorf("%d %s Failed: [%s] validation should have passed, error: %v", idx, t.Name(), v.Description, err)
+		}
+
+		if aips := NewFromAllTapes(tapes); len(aips) != 1 {
+			t.Errorf("%d %s Failed: [%s] expected [1] aip but got [%d]", idx, t.Name(), v.Description, len(aips))
+		} else if valid, err := aips[0].Validate(); !valid {
+			t.Errorf("%d %s Failed: [%s] validation should have passed, error: %v", idx, t.Name(), v.Description, err)
+		}
+	}
+}
+
+// TestVectors_SignBobOpReturnData will test SignBobOpReturnData() against the corpus
+func TestVectors_SignBobOpReturnData(t *testing.T) {
+	t.Parallel()
+
+	for idx, v := range loadTestVectors(t) {
+		if !isPiped(v) {
+			continue
+		}
+		priv, err := v.Key()
+		if err != nil {
+			t.Fatalf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+		}
+
+		// Drop the existing AIP tape and sign the remaining data
+		tapes := vectorTapes(t, v)
+		var output bpu.Output
+		output.Tape = tapes[:len(tapes)-1]
+
+		var a *Aip
+		if _, a, err = SignBobOpReturnData(priv, v.Algorithm, output); err != nil {
+			t.Errorf("%d %s Failed: [%s] error not expected but got: %s", idx, t.Name(), v.Description, err.Error())
+		} else if a.AlgorithmSigningComponent != v.SigningComponent {
+			t.Errorf("%d %s Failed: [%s] expected [%s] but got [%s]", idx, t.Name(), v.Description, v.SigningComponent, a.AlgorithmSigningComponent)
+		} else if valid, err := a.Validate(); !valid {
+			t.Errorf("%d %s Failed: [%s] validation should have passed, error: %v", idx, t.Name(), v.Description, err)
+		}
+	}
+}
+
+// ExampleVectors example using Vectors()
+func ExampleVectors() {
+	vectors, err := Vectors()
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("%s: %s %s", vectors[0].Description, vectors[0].SigningComponent, vectors[0].Signature)
+	// Output:single text part: 1DfGxKmgL3ETwUdNnXLBueEvNpjcDGcKgK INQwm/7FV7S5wzDf4L+HayG8PVhenwgeZ0T5QuNnVGbtSe+7L+Um7lxcrjsj7eMi3N4K1dAOqrVbkESkQfV7odc=
+}